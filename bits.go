@@ -0,0 +1,102 @@
+package zermelo
+
+import (
+	"cmp"
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+)
+
+// SortBits sorts x by only the bits of its elements from lo up to but not including hi, keeping elements with equal
+// bits in their original order. Bit 0 is the least significant. The bits are compared as an unsigned number, except
+// that when they include the sign bit of a signed type, negative elements come first, so SortBits(x, 0, size) sorts
+// as Sort does. Only the radix passes covering those bits are run, using a buffer borrowed from a pool.
+// SortBits panics if hi is less than lo or more than the size of T in bits.
+func SortBits[T Integer](x []T, lo, hi uint) {
+	size, minval := internal.Detect[T]()
+	if hi < lo || hi > size {
+		panic("zermelo: SortBits bit range out of bounds")
+	}
+	if len(x) < 2 || lo == hi {
+		return
+	}
+	f := newBitField(size, lo, hi, minval != 0)
+	if len(x) < cutoffs.Get(size) {
+		ob := startObserving(context.Background(), nil, Comparison, len(x), size)
+		slices.SortStableFunc(x, func(a, b T) int {
+			return cmp.Compare(f.value(uint64(a)), f.value(uint64(b)))
+		})
+		ob.finish(0, nil)
+		return
+	}
+	ob := startObserving(context.Background(), nil, Radix, len(x), size)
+	buf, allocated := internal.GetBuffer[T](len(x))
+	if allocated {
+		ob.alloc(len(*buf) * int(size/8))
+	}
+	_ = bitsRadixSort(x, *buf, f, ob.passFunc())
+	internal.PutBuffer(buf)
+	ob.finish(f.passes(), nil)
+}
+
+// bitField describes the bits from lo up to hi of elements that are size bits wide, see SortBits.
+type bitField struct {
+	lo, hi uint
+	flip   uint64 // sign bit, if signed and in the field
+}
+
+func newBitField(size, lo, hi uint, signed bool) bitField {
+	f := bitField{lo: lo, hi: hi}
+	if signed && hi == size {
+		f.flip = 1 << (size - 1)
+	}
+	return f
+}
+
+// value returns the bits of elem in the field, shifted down to bit 0.
+func (f bitField) value(elem uint64) uint64 {
+	return (elem ^ f.flip) << (64 - f.hi) >> (64 - f.hi + f.lo)
+}
+
+// passes returns the number of byte wide radix passes covering the field.
+func (f bitField) passes() int {
+	return int((f.hi - f.lo + radix - 1) / radix)
+}
+
+// bitsRadixSort is lsdRadixSort keyed by only the bits in f, with the bytes counted from bit f.lo up.
+// The last byte is narrower if the field is not a whole number of bytes.
+func bitsRadixSort[T Integer](x, buffer []T, f bitField, pass passFunc) error {
+	var counts [maxPasses][256]int
+	passes := f.passes()
+	var (
+		prev   uint64
+		sorted = true
+	)
+	for _, elem := range x {
+		v := f.value(uint64(elem))
+		for p := 0; p < passes; p++ {
+			counts[p][uint8(v>>(uint(p)*radix))]++
+		}
+		if sorted { // Detect sorted
+			sorted = v >= prev
+			prev = v
+		}
+	}
+	if sorted {
+		return nil
+	}
+
+	var moving [maxPasses]int
+	return scatterPasses(x, buffer, movingPasses(&moving, passes, len(x), func(p int) []int {
+		return counts[p][:]
+	}), 0, pass, func(from, to []T, p int, _, _ bool) {
+		offset := &counts[p]
+		bucketOffsets(offset, false)
+		shift := uint(p) * radix
+		for _, elem := range from {
+			key := uint8(f.value(uint64(elem)) >> shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	})
+}
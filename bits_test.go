@@ -0,0 +1,67 @@
+package zermelo
+
+import (
+	"cmp"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortBits(t *testing.T) {
+	testSortBits[uint64](t, 0, 64)
+	testSortBits[uint64](t, 4, 20)
+	testSortBits[uint64](t, 56, 64)
+	testSortBits[uint64](t, 61, 64)
+	testSortBits[int64](t, 0, 64)
+	testSortBits[int64](t, 60, 64)
+	testSortBits[int32](t, 3, 29)
+	testSortBits[int16](t, 8, 16)
+	testSortBits[uint8](t, 0, 3)
+}
+
+func testSortBits[T Integer](t *testing.T, lo, hi uint) {
+	size, minval := internal.Detect[T]()
+	f := newBitField(size, lo, hi, minval != 0)
+	for _, n := range []int{0, 1, 2, compSortCutoff - 1, testSize} {
+		x := make([]T, n)
+		internal.FillSlice(x, internal.RandInteger[T]())
+		control := slices.Clone(x)
+		slices.SortStableFunc(control, func(a, b T) int {
+			return cmp.Compare(f.value(uint64(a)), f.value(uint64(b)))
+		})
+		SortBits(x, lo, hi)
+		if !slices.Equal(control, x) {
+			t.Fatalf("%T: sort of %d by bits [%d, %d) failed", T(0), n, lo, hi)
+		}
+		if lo == 0 && hi == size && !slices.IsSorted(x) {
+			t.Fatalf("%T: sort of %d by all bits is not sorted", T(0), n)
+		}
+	}
+}
+
+func TestSortBitsOutOfBounds(t *testing.T) {
+	for _, r := range [][2]uint{{4, 3}, {0, 33}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("out of bounds bit range did not panic", r)
+				}
+			}()
+			SortBits(make([]uint32, 10), r[0], r[1])
+		}()
+	}
+}
+
+func TestSortBitsStats(t *testing.T) {
+	var stats []Stats
+	SetObserver(func(s Stats) {
+		stats = append(stats, s)
+	})
+	defer SetObserver(nil)
+	x := make([]uint64, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint64]())
+	SortBits(x, 48, 64)
+	if len(stats) != 1 || stats[0].Algorithm != Radix || stats[0].Passes != 2 || stats[0].SkippedPasses != 0 {
+		t.Fatal("wrong stats", stats)
+	}
+}
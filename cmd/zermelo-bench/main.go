@@ -0,0 +1,268 @@
+// Command zermelo-bench measures zermelo against the standard library on the current machine.
+//
+// For each element type and data distribution it times slices.Sort, zermelo.Sort, a Sorter and a plain radix sort
+// with a reused buffer over a range of slice sizes, reports where radix sort starts to win, and recommends values for
+// the comparison sort cutoffs.
+//
+//	go run github.com/shawnsmithdev/zermelo/v2/cmd/zermelo-bench -max 8192 -time 100ms
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/floats"
+)
+
+// batchElems is roughly how many elements are sorted between two clock reads, so timer overhead stays small.
+const batchElems = 1 << 14
+
+var (
+	minSize = flag.Int("min", 16, "smallest slice size to measure")
+	maxSize = flag.Int("max", 4096, "largest slice size to measure")
+	steps   = flag.Int("steps", 4, "sizes measured per doubling")
+	budget  = flag.Duration("time", 20*time.Millisecond, "minimum time spent per measurement")
+	dists   = flag.String("dist", "random,sorted,reversed,narrow", "comma separated data distributions to measure")
+	seed    = flag.Int64("seed", 1, "random seed for generated data")
+	verbose = flag.Bool("v", false, "print timings for every size, not just the crossovers")
+)
+
+// order is the arrangement of values in generated test data.
+type order int
+
+const (
+	unordered order = iota
+	ascending
+	descending
+)
+
+// distribution describes generated test data: random values masked by mask, arranged in the given order.
+type distribution struct {
+	name  string
+	mask  uint64
+	order order
+}
+
+var distributions = []distribution{
+	{"random", math.MaxUint64, unordered},
+	{"sorted", math.MaxUint64, ascending},
+	{"reversed", math.MaxUint64, descending},
+	{"narrow", 0xff, unordered},
+}
+
+// algorithms measured, in column order
+var algorithms = []string{"slices.Sort", "Sort", "Sorter", "radix"}
+
+// result holds nanoseconds per element for each algorithm at one size.
+type result struct {
+	size int
+	ns   [4]float64
+}
+
+// report is everything measured for one element type and distribution.
+type report struct {
+	typeName string
+	cutoff   string // name of the cutoff this type's crossover recommends
+	current  int    // process-wide cutoff currently in use for this type
+	dist     string
+	results  []result
+}
+
+func main() {
+	flag.Parse()
+	if *minSize < 2 || *maxSize < *minSize || *steps < 1 {
+		fmt.Fprintln(os.Stderr, "zermelo-bench: need 2 <= min <= max and steps >= 1")
+		os.Exit(2)
+	}
+	selected, err := selectDistributions(*dists)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zermelo-bench:", err)
+		os.Exit(2)
+	}
+	sizes := sizeRange(*minSize, *maxSize, *steps)
+	rng := rand.New(rand.NewSource(*seed))
+
+	var reports []report
+	for _, d := range selected {
+		reports = append(reports,
+			measure[uint32]("uint32", "compSortCutoff", zermelo.Cutoff[uint32](), d, sizes, rng,
+				func(v uint64) uint32 { return uint32(v) },
+				zermelo.Sort[uint32], zermelo.NewSorter[uint32]().Sort, zermelo.SortBYOB[uint32]),
+			measure[uint64]("uint64", "compSortCutoff64", zermelo.Cutoff[uint64](), d, sizes, rng,
+				func(v uint64) uint64 { return v },
+				zermelo.Sort[uint64], zermelo.NewSorter[uint64]().Sort, zermelo.SortBYOB[uint64]),
+			measure[float32]("float32", "compSortCutoffFloat32", floats.Cutoff[float32](), d, sizes, rng,
+				func(v uint64) float32 { return float32(int32(v)) },
+				floats.SortFloats[float32], floats.NewFloatSorter[float32]().Sort, floats.SortFloatsBYOB[float32]),
+			measure[float64]("float64", "compSortCutoffFloat64", floats.Cutoff[float64](), d, sizes, rng,
+				func(v uint64) float64 { return float64(int64(v)) },
+				floats.SortFloats[float64], floats.NewFloatSorter[float64]().Sort, floats.SortFloatsBYOB[float64]),
+		)
+	}
+
+	if *verbose {
+		for _, r := range reports {
+			printTimings(os.Stdout, r)
+		}
+	}
+	printCrossovers(os.Stdout, reports)
+	printRecommendations(os.Stdout, reports)
+}
+
+// selectDistributions parses the -dist flag.
+func selectDistributions(names string) ([]distribution, error) {
+	var result []distribution
+	for _, name := range strings.Split(names, ",") {
+		idx := slices.IndexFunc(distributions, func(d distribution) bool { return d.name == name })
+		if idx < 0 {
+			return nil, fmt.Errorf("unknown distribution %q", name)
+		}
+		result = append(result, distributions[idx])
+	}
+	return result, nil
+}
+
+// sizeRange returns sizes from lo to hi, growing geometrically with the given number of steps per doubling.
+func sizeRange(lo, hi, steps int) []int {
+	var result []int
+	for i := 0; ; i++ {
+		size := int(math.Round(float64(lo) * math.Pow(2, float64(i)/float64(steps))))
+		if size > hi {
+			return result
+		}
+		if len(result) == 0 || result[len(result)-1] != size {
+			result = append(result, size)
+		}
+	}
+}
+
+// measure times each algorithm for one element type and distribution at every size.
+func measure[T cmp.Ordered](typeName, cutoff string, current int, d distribution, sizes []int, rng *rand.Rand,
+	conv func(uint64) T, sortFunc, sorterFunc func([]T), byobFunc func(x, buf []T)) report {
+	rep := report{typeName: typeName, cutoff: cutoff, current: current, dist: d.name}
+	for _, size := range sizes {
+		input := make([]T, size)
+		for i := range input {
+			input[i] = conv(rng.Uint64() & d.mask)
+		}
+		switch d.order {
+		case ascending:
+			slices.Sort(input)
+		case descending:
+			slices.Sort(input)
+			slices.Reverse(input)
+		}
+		buf := make([]T, size)
+		radix := func(x []T) { byobFunc(x, buf) }
+		rep.results = append(rep.results, result{size: size, ns: [4]float64{
+			timeSort(slices.Sort[[]T], input),
+			timeSort(sortFunc, input),
+			timeSort(sorterFunc, input),
+			timeSort(radix, input),
+		}})
+	}
+	return rep
+}
+
+// timeSort returns the mean time in nanoseconds per element taken by sortFunc to sort copies of input.
+func timeSort[T any](sortFunc func([]T), input []T) float64 {
+	batch := max(1, batchElems/len(input))
+	work := make([][]T, batch)
+	for i := range work {
+		work[i] = make([]T, len(input))
+	}
+	var elapsed time.Duration
+	var sorted int
+	for elapsed < *budget {
+		for _, w := range work {
+			copy(w, input)
+		}
+		start := time.Now()
+		for _, w := range work {
+			sortFunc(w)
+		}
+		elapsed += time.Since(start)
+		sorted += batch * len(input)
+	}
+	return float64(elapsed.Nanoseconds()) / float64(sorted)
+}
+
+// crossover returns the smallest measured size from which algorithm alg is faster than slices.Sort at every larger
+// measured size, or -1 if it never is.
+func crossover(results []result, alg int) int {
+	found := -1
+	for i := len(results) - 1; i >= 0; i-- {
+		if results[i].ns[alg] >= results[i].ns[0] {
+			break
+		}
+		found = results[i].size
+	}
+	return found
+}
+
+func printTimings(w io.Writer, r report) {
+	fmt.Fprintf(w, "%s, %s (ns/element)\n", r.typeName, r.dist)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "size\t%s\t\n", strings.Join(algorithms, "\t"))
+	for _, res := range r.results {
+		fmt.Fprintf(tw, "%d", res.size)
+		for _, ns := range res.ns {
+			fmt.Fprintf(tw, "\t%.2f", ns)
+		}
+		fmt.Fprintln(tw, "\t")
+	}
+	_ = tw.Flush()
+	fmt.Fprintln(w)
+}
+
+func printCrossovers(w io.Writer, reports []report) {
+	fmt.Fprintln(w, "smallest size from which each algorithm beats slices.Sort:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "type\tdist\t%s\n", strings.Join(algorithms[1:], "\t"))
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s\t%s", r.typeName, r.dist)
+		for alg := 1; alg < len(algorithms); alg++ {
+			fmt.Fprintf(tw, "\t%s", formatSize(crossover(r.results, alg)))
+		}
+		fmt.Fprintln(tw)
+	}
+	_ = tw.Flush()
+	fmt.Fprintln(w)
+}
+
+// printRecommendations suggests cutoffs from the radix crossover on random data, as the other distributions
+// are either short-circuited or favor comparison sort regardless of size.
+func printRecommendations(w io.Writer, reports []report) {
+	fmt.Fprintln(w, "recommended cutoffs (radix crossover on random data):")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var printed bool
+	for _, r := range reports {
+		if r.dist != "random" {
+			continue
+		}
+		printed = true
+		fmt.Fprintf(tw, "%s\t= %s\t(currently %d, see SetCutoff[%s])\n",
+			r.cutoff, formatSize(crossover(r.results, len(algorithms)-1)), r.current, r.typeName)
+	}
+	if !printed {
+		fmt.Fprintln(tw, "none, random distribution was not measured")
+	}
+	_ = tw.Flush()
+}
+
+func formatSize(size int) string {
+	if size < 0 {
+		return fmt.Sprintf(">%d", *maxSize)
+	}
+	return fmt.Sprint(size)
+}
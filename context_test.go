@@ -0,0 +1,94 @@
+package zermelo
+
+import (
+	"context"
+	"errors"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortContext(t *testing.T) {
+	for _, n := range []int{0, 1, compSortCutoff64 - 1, testSize} {
+		x := make([]int32, n)
+		internal.FillSlice(x, internal.RandInteger[int32]())
+		if err := SortContext(context.Background(), x); err != nil || !slices.IsSorted(x) {
+			t.Fatal("sort failed", err, x)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	x := make([]int64, testSize)
+	internal.FillSlice(x, internal.RandInteger[int64]())
+	control := slices.Clone(x)
+	if err := SortContext(ctx, x); !errors.Is(err, context.Canceled) || !slices.Equal(x, control) {
+		t.Fatal("cancelled sort changed x or did not fail", err)
+	}
+}
+
+func TestSorterCancel(t *testing.T) {
+	for _, stopAfter := range []int{1, 2, 3, 7} {
+		testSorterCancel[int64](t, stopAfter, 1)
+		testSorterCancel[uint64](t, stopAfter, 4)
+	}
+	testSorterCancel[uint32](t, 1, 1)
+	testSorterCancel[uint32](t, 2, 1)
+}
+
+// testSorterCancel checks that cancelling after stopAfter passes leaves x a permutation of its elements.
+func testSorterCancel[I Integer](t *testing.T, stopAfter, parallelism int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	test := NewSorterWith[I](WithCutoff(0), WithParallelism(parallelism), WithProgress(func(done, total int) {
+		if done == stopAfter {
+			cancel()
+		}
+	})).(ContextSorter[I])
+	x := make([]I, 4*parallelMinChunk)
+	internal.FillSlice(x, internal.RandInteger[I]())
+	control := slices.Clone(x)
+	slices.Sort(control)
+	if err := test.SortContext(ctx, x); !errors.Is(err, context.Canceled) {
+		t.Fatalf("%T: sort not cancelled after %d passes: %v", I(0), stopAfter, err)
+	}
+	slices.Sort(x)
+	if !slices.Equal(x, control) {
+		t.Fatalf("%T: cancelled after %d passes, x is not a permutation", I(0), stopAfter)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	var calls [][2]int
+	test := NewSorterWith[uint32](WithProgress(func(done, total int) {
+		calls = append(calls, [2]int{done, total})
+	}))
+	sortAndCheck[uint32](t, test, compSortCutoff-1)
+	if !slices.Equal(calls, [][2]int{{1, 1}}) {
+		t.Fatal("wrong comparison sort progress", calls)
+	}
+
+	calls = nil
+	sortAndCheck[uint32](t, test, testSize)
+	if !slices.Equal(calls, [][2]int{{1, 4}, {2, 4}, {3, 4}, {4, 4}}) {
+		t.Fatal("wrong radix sort progress", calls)
+	}
+
+	calls = nil
+	x := make([]uint32, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint32]())
+	slices.Sort(x)
+	test.Sort(x) // already sorted, one run to merge
+	if !slices.Equal(calls, [][2]int{{1, 1}}) {
+		t.Fatal("wrong presorted progress", calls)
+	}
+}
+
+func TestConcurrentSorterContext(t *testing.T) {
+	test := NewConcurrentSorter[uint16]().(ContextSorter[uint16])
+	x := make([]uint16, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint16]())
+	if err := test.SortContext(context.Background(), x); err != nil || !slices.IsSorted(x) {
+		t.Fatal("sort failed", err)
+	}
+}
@@ -0,0 +1,52 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// countingSortMin16 is the fewest 16 bit elements to sort with countingSort, which must read all 65536 counts.
+const countingSortMin16 = 1 << 16
+
+// useCountingSort returns true if n elements that are size bits wide should be sorted with countingSort.
+func useCountingSort(size uint, n int) bool {
+	return size == 8 || (size == 16 && n >= countingSortMin16)
+}
+
+// countingSort sorts x, of 8 or 16 bit elements, by counting each value and then rewriting x from the counts,
+// without a buffer. It is one pass over x, calling pass after it if it is not nil.
+func countingSort[T Integer](x []T, size uint, minval T, pass passFunc) error {
+	if size == 8 {
+		var counts [256]int
+		countValues(x, counts[:], minval)
+	} else {
+		counts, _ := internal.GetBuffer[int](1 << 16)
+		clear(*counts)
+		countValues(x, (*counts)[:1<<16], minval)
+		internal.PutBuffer(counts)
+	}
+	if pass != nil {
+		return pass(1)
+	}
+	return nil
+}
+
+// countValues counts each value of x, offset from minval, then rewrites x with each value as many times as counted.
+// The length of counts must be a power of two, covering all values.
+func countValues[T Integer](x []T, counts []int, minval T) {
+	mask := uint64(len(counts) - 1)
+	for _, elem := range x {
+		counts[uint64(elem-minval)&mask]++
+	}
+	rewriteCounts(x, counts, minval)
+}
+
+// rewriteCounts fills x with base+key as many times as counts[key], for each key in order.
+func rewriteCounts[T Integer](x []T, counts []int, base T) {
+	i := 0
+	for key, count := range counts {
+		value := T(key) + base
+		for end := i + count; i < end; i++ {
+			x[i] = value
+		}
+	}
+}
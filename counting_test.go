@@ -0,0 +1,65 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestCountingSort(t *testing.T) {
+	testCountingSort[uint8](t, internal.RandInteger[uint8]())
+	testCountingSort[int8](t, internal.RandInteger[int8]())
+	testCountingSort[uint16](t, internal.RandInteger[uint16]())
+	testCountingSort[int16](t, internal.RandInteger[int16]())
+	testCountingSort[int16](t, func() int16 { return int16(internal.RandInteger[int8]()()) })
+}
+
+func testCountingSort[T Integer](t *testing.T, gen func() T) {
+	size, minval := internal.Detect[T]()
+	for _, n := range []int{0, 1, 2, testSize, countingSortMin16} {
+		x := make([]T, n)
+		internal.FillSlice(x, gen)
+		control := slices.Clone(x)
+		slices.Sort(control)
+		passes := 0
+		err := countingSort(x, size, minval, func(done int) error {
+			passes = done
+			return nil
+		})
+		if err != nil || passes != 1 {
+			t.Fatal("wrong counting sort passes", err, passes)
+		}
+		if !slices.Equal(control, x) {
+			t.Fatalf("%T: counting sort of %d failed", T(0), n)
+		}
+	}
+}
+
+func TestSortCounting(t *testing.T) {
+	var stats []Stats
+	test := NewSorterWith[int16](WithObserver(func(s Stats) {
+		stats = append(stats, s)
+	}))
+	x := make([]int16, countingSortMin16)
+	internal.FillSlice(x, internal.RandInteger[int16]())
+	control := slices.Clone(x)
+	slices.Sort(control)
+	test.Sort(x)
+	if !slices.Equal(control, x) {
+		t.Fatal("counting sort failed")
+	}
+	if len(stats) != 1 || stats[0].Algorithm != Counting || stats[0].Passes != 1 || stats[0].Allocs != 0 {
+		t.Fatal("wrong stats", stats)
+	}
+	if test.(BufferedSorter[int16]).BufferBytes() != 0 {
+		t.Fatal("counting sort allocated a buffer")
+	}
+
+	// A buffer too short for radix sort is not used
+	bytes := make([]uint8, 1000)
+	internal.FillSlice(bytes, internal.RandInteger[uint8]())
+	SortBYOB(bytes, nil)
+	if !slices.IsSorted(bytes) {
+		t.Fatal("SortBYOB counting sort failed")
+	}
+}
@@ -0,0 +1,32 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// cutoffs holds the process-wide comparison sort cutoffs used by Sort and by Sorters without WithCutoff.
+var cutoffs = internal.NewCutoffs(compSortCutoff, compSortCutoff, compSortCutoff, compSortCutoff64)
+
+// Cutoff returns the process-wide comparison sort cutoff for T. Slices shorter than this are sorted with
+// SmallSort instead of radix sort. All integer types of the same size share a cutoff.
+func Cutoff[T Integer]() int {
+	size, _ := internal.Detect[T]()
+	return cutoffs.Get(size)
+}
+
+// SetCutoff sets the process-wide comparison sort cutoff for all integer types of the same size as T.
+// This affects Sort and all Sorters not created with WithCutoff, including those already created.
+func SetCutoff[T Integer](cutoff int) {
+	size, _ := internal.Detect[T]()
+	cutoffs.Set(size, cutoff)
+}
+
+// Calibrate measures radix sort against comparison sort on this machine for 8, 16, 32 and 64 bit integers,
+// and sets the process-wide cutoffs to the measured crossover points. It takes a fraction of a second,
+// and is intended to be called once at startup.
+func Calibrate() {
+	SetCutoff[uint8](internal.Crossover(internal.RandInteger[uint8](), SortBYOB[uint8]))
+	SetCutoff[uint16](internal.Crossover(internal.RandInteger[uint16](), SortBYOB[uint16]))
+	SetCutoff[uint32](internal.Crossover(internal.RandInteger[uint32](), SortBYOB[uint32]))
+	SetCutoff[uint64](internal.Crossover(internal.RandInteger[uint64](), SortBYOB[uint64]))
+}
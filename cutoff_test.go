@@ -0,0 +1,46 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"testing"
+)
+
+func TestSetCutoff(t *testing.T) {
+	defer SetCutoff[uint64](compSortCutoff64)
+	if Cutoff[uint64]() != compSortCutoff64 || Cutoff[int32]() != compSortCutoff {
+		t.Fatal("wrong default cutoffs", Cutoff[uint64](), Cutoff[int32]())
+	}
+	SetCutoff[uint64](10)
+	if Cutoff[int64]() != 10 {
+		t.Fatal("cutoff not shared by same size types", Cutoff[int64]())
+	}
+	if Cutoff[uint32]() != compSortCutoff {
+		t.Fatal("cutoff changed for other size", Cutoff[uint32]())
+	}
+	SetCutoff[uint64](-1)
+	if Cutoff[uint64]() != 0 {
+		t.Fatal("negative cutoff not clamped", Cutoff[uint64]())
+	}
+	testSort[uint64](t, internal.RandInteger[uint64](), false)
+	testSorter[uint64](t, internal.RandInteger[uint64](), true)
+}
+
+func TestCalibrate(t *testing.T) {
+	defer func() {
+		SetCutoff[uint8](compSortCutoff)
+		SetCutoff[uint16](compSortCutoff)
+		SetCutoff[uint32](compSortCutoff)
+		SetCutoff[uint64](compSortCutoff64)
+	}()
+	Calibrate()
+	for size, cutoff := range map[uint]int{
+		8: Cutoff[int8](), 16: Cutoff[int16](), 32: Cutoff[int32](), 64: Cutoff[int64](),
+	} {
+		t.Logf("%d bit cutoff: %d", size, cutoff)
+		if cutoff <= 0 {
+			t.Fatalf("%d bit cutoff not calibrated", size)
+		}
+	}
+	testSort[int32](t, internal.RandInteger[int32](), false)
+	testSort[uint64](t, internal.RandInteger[uint64](), false)
+}
@@ -0,0 +1,251 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// Wider radix digits mean fewer passes over the slice, but larger histograms and more scattering targets, which only
+// pay off once the slice is much larger than the histograms.
+//
+// Digits are as wide as the number of elements sorted least significant digit first calls for. All of these minimums
+// are beyond hybridMin, so slices this large are partitioned first, and wide digits sort the buckets that are still
+// this large, as when keys such as timestamps or IDs cluster in few values of the partition byte.
+const (
+	wideRadix      uint = 11      // 3 passes for 32 bit elements, 6 for 64 bit
+	wideRadixMin        = 1 << 21 // fewest 32 or 64 bit elements to use wideRadix digits
+	widestRadix    uint = 16      // 2 passes for 32 bit elements, 4 for 64 bit
+	widestRadixMin      = 1 << 24 // fewest 32 or 64 bit elements to use widestRadix digits
+)
+
+// digitBits returns the width of radix digits used to sort n elements that are size bits wide.
+func digitBits(size uint, n int) uint {
+	switch {
+	case size > 16 && n >= widestRadixMin:
+		return widestRadix
+	case size > 16 && n >= wideRadixMin:
+		return wideRadix
+	}
+	return radix
+}
+
+// radixPasses returns the number of radix sort passes needed to sort n elements that are size bits wide.
+// Partitioned slices are counted in byte passes, see hybridSort.
+func radixPasses(size uint, n int) int {
+	if useHybrid(size, n) {
+		return int(size / radix)
+	}
+	return newDigits(size, digitBits(size, n), false).passes
+}
+
+// digits describes how elements are split into radix digits of a given width, least significant first.
+// The sign bit of signed elements is flipped, so negative elements have smaller digits than positive ones.
+type digits struct {
+	size   uint // of elements, in bits
+	bits   uint
+	passes int
+	flip   uint64 // sign bit, if signed
+	mask   uint64 // the size bits of an element, masking off sign extension
+}
+
+func newDigits(size, bits uint, signed bool) digits {
+	d := digits{
+		size:   size,
+		bits:   bits,
+		passes: int((size + bits - 1) / bits),
+		mask:   ^uint64(0) >> (64 - size),
+	}
+	if signed {
+		d.flip = 1 << (size - 1)
+	}
+	return d
+}
+
+// buckets returns the number of distinct digits.
+func (d digits) buckets() int {
+	return 1 << d.bits
+}
+
+// histograms returns zeroed counts for every digit of every pass, as digitHistograms fills, borrowed from a pool
+// along with the pooled buffer holding them, to be returned with internal.PutBuffer.
+// With 16 bit digits these are megabytes, too much to allocate on every sort.
+func (d digits) histograms() (*[]int, []int) {
+	pooled, _ := internal.GetBuffer[int](d.passes * d.buckets())
+	counts := (*pooled)[:d.passes*d.buckets()]
+	clear(counts)
+	return pooled, counts
+}
+
+// key returns the digit of elem shifted right by shift bits.
+func (d digits) key(elem uint64, shift uint) int {
+	return int(((elem ^ d.flip) & d.mask >> shift) & (1<<d.bits - 1))
+}
+
+// digitHistograms counts the digits of the elements of x for every pass at once, with the counts for pass p
+// in counts[p*d.buckets():]. It returns true if x is sorted. If floatBits is not zero, elements are the bits of
+// floats that wide, counted and compared as flipFloat of them.
+func digitHistograms[T Integer](x []T, counts []int, d digits, minval T, floatBits uint) bool {
+	var (
+		prev   = minval
+		sorted = true
+	)
+	digitMask := uint64(d.buckets() - 1)
+	for _, elem := range x {
+		elem = floatKey(elem, floatBits)
+		u := (uint64(elem) ^ d.flip) & d.mask
+		for base := 0; u != 0; base += d.buckets() { // Zero digits are counted below
+			if key := int(u & digitMask); key != 0 {
+				counts[base+key]++
+			}
+			u >>= d.bits
+		}
+		if sorted { // Detect sorted
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+	// Every element not counted in a pass has a zero digit
+	for base := 0; base < len(counts); base += d.buckets() {
+		zeros := len(x)
+		for _, count := range counts[base+1 : base+d.buckets()] {
+			zeros -= count
+		}
+		counts[base] = zeros
+	}
+	return sorted
+}
+
+// wideRadixSort is lsdRadixSort with digits wider than a byte, for large slices.
+func wideRadixSort[T Integer](x, buffer []T, size, bits uint, minval T, pass passFunc) error {
+	d := newDigits(size, bits, minval != 0)
+	pooled, counts := d.histograms()
+	defer internal.PutBuffer(pooled)
+	if digitHistograms(x, counts, d, minval, 0) { // Short-circuit sorted
+		return nil
+	}
+
+	var stage stager[T]
+	if useStagedScatter(d, len(x)) {
+		stage = newStager[T](d)
+		defer stage.release()
+	}
+	var moving [maxPasses]int
+	return scatterPasses(x, buffer, movingPasses(&moving, d.passes, len(x), func(p int) []int {
+		return counts[p*d.buckets() : (p+1)*d.buckets()]
+	}), 0, pass, func(from, to []T, p int, _, _ bool) {
+		offset := counts[p*d.buckets() : (p+1)*d.buckets()]
+		shift := uint(p) * d.bits
+
+		var watermark int
+		for key, count := range offset {
+			offset[key] = watermark
+			watermark += count
+		}
+
+		if stage.pooled != nil {
+			stage.scatter(from, to, offset, d, shift, 0)
+		} else {
+			scatterDigits(from, to, offset, d, shift, 0, false, false)
+		}
+	})
+}
+
+// scatterDigits moves each element of from to to[offset[key]], where key is its digit shifted right by shift bits,
+// incrementing offset[key]. If floatBits is not zero, elements are the bits of floats that wide, flipped as they are
+// keyed if flip, and unflipped as they are stored if unflip, see floatScatter.
+func scatterDigits[T Integer](from, to []T, offset []int, d digits, shift, floatBits uint, flip, unflip bool) {
+	switch {
+	case floatBits == 0 || (!flip && !unflip):
+		for _, elem := range from {
+			key := d.key(uint64(elem), shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	case flip && unflip:
+		for _, elem := range from {
+			key := d.key(flipFloat(uint64(elem), floatBits), shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	case flip:
+		for _, elem := range from {
+			elem = T(flipFloat(uint64(elem), floatBits))
+			key := d.key(uint64(elem), shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	default:
+		for _, elem := range from {
+			key := d.key(uint64(elem), shift)
+			to[offset[key]] = T(unflipFloat(uint64(elem), floatBits))
+			offset[key]++
+		}
+	}
+}
+
+// stagedScatterMin is the fewest bytes of elements to scatter through staging buffers, see stager.
+// Below the size of the last level CPU cache, staging only adds work. Slices this large are partitioned by
+// hybridSort, whose partition pass is staged, and LSD sorted only as buckets.
+const stagedScatterMin = 1 << 25
+
+// cacheLine is the size in bytes of a CPU cache line, the unit a stager writes in.
+const cacheLine = 64
+
+// useStagedScatter returns true if n elements should be scattered by the digits d through a stager.
+// Staging areas for digits wider than wideRadix, one cache line per digit, are too large to stay in cache.
+func useStagedScatter(d digits, n int) bool {
+	return d.bits <= wideRadix && n*int(d.size/8) >= stagedScatterMin
+}
+
+// stager scatters elements through a cache line sized staging buffer per digit. Rather than writing each element to
+// a random place in the target, elements are staged, and written to the target a whole line at a time.
+// On slices much larger than the CPU cache this avoids most cache and TLB misses.
+// Its buffers are reused for every pass of a sort, and must be returned with release.
+type stager[T Integer] struct {
+	pooled *[]T
+	stage  []T
+	staged []int // next free index in stage for each digit
+	line   int   // elements per cache line
+}
+
+func newStager[T Integer](d digits) stager[T] {
+	line := cacheLine / int(d.size/8)
+	pooled, _ := internal.GetBuffer[T](d.buckets() * line)
+	return stager[T]{
+		pooled: pooled,
+		stage:  (*pooled)[:d.buckets()*line],
+		staged: make([]int, d.buckets()),
+		line:   line,
+	}
+}
+
+// release returns the staging buffer to its pool.
+func (s *stager[T]) release() {
+	internal.PutBuffer(s.pooled)
+}
+
+// scatter copies each element of from to to[offset[key]], where key is its digit shifted right by shift bits,
+// incrementing offset[key], like the scatter loop of lsdRadixSort. If floatBits is not zero, elements are the bits
+// of floats that wide, flipped as they are staged, see flipFloat.
+func (s *stager[T]) scatter(from, to []T, offset []int, d digits, shift, floatBits uint) {
+	line, stage, staged := s.line, s.stage, s.staged
+	for key := range staged {
+		staged[key] = key * line
+	}
+	for _, elem := range from {
+		elem = floatKey(elem, floatBits)
+		key := d.key(uint64(elem), shift)
+		i := staged[key]
+		stage[i] = elem
+		if i++; i&(line-1) == 0 { // Line full, write it out and start it over
+			i -= line
+			copy(to[offset[key]:offset[key]+line], stage[i:i+line])
+			offset[key] += line
+		}
+		staged[key] = i
+	}
+	for key, i := range staged {
+		n := i - key*line
+		copy(to[offset[key]:], stage[key*line:i])
+		offset[key] += n
+	}
+}
@@ -0,0 +1,155 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDigitBits(t *testing.T) {
+	for _, test := range []struct {
+		size     uint
+		n        int
+		expected uint
+	}{
+		{8, widestRadixMin, radix},
+		{16, widestRadixMin, radix},
+		{32, wideRadixMin - 1, radix},
+		{32, wideRadixMin, wideRadix},
+		{32, widestRadixMin, widestRadix},
+		{64, wideRadixMin - 1, radix},
+		{64, wideRadixMin, wideRadix},
+		{64, widestRadixMin, widestRadix},
+	} {
+		if bits := digitBits(test.size, test.n); bits != test.expected {
+			t.Errorf("digitBits(%d, %d) = %d, expected %d", test.size, test.n, bits, test.expected)
+		}
+	}
+}
+
+func TestWideRadixSort(t *testing.T) {
+	for _, bits := range []uint{wideRadix, widestRadix} {
+		testWideRadixSort[int16](t, internal.RandInteger[int16](), bits)
+		testWideRadixSort[int32](t, internal.RandInteger[int32](), bits)
+		testWideRadixSort[int64](t, internal.RandInteger[int64](), bits)
+		testWideRadixSort[uint32](t, internal.RandInteger[uint32](), bits)
+		testWideRadixSort[uint64](t, internal.RandInteger[uint64](), bits)
+		testWideRadixSort[int64](t, func() int64 { return int64(internal.RandInteger[int8]()()) }, bits)
+		testWideRadixSort[uint64](t, func() uint64 { return uint64(internal.RandInteger[uint8]()()) << 33 }, bits)
+	}
+}
+
+func testWideRadixSort[T Integer](t *testing.T, gen func() T, bits uint) {
+	size, minval := internal.Detect[T]()
+	for _, n := range []int{2, 3, testSize, 5000} {
+		toTest := make([]T, n)
+		internal.FillSlice(toTest, gen)
+		control := slices.Clone(toTest)
+		slices.Sort(control)
+		if err := wideRadixSort(toTest, make([]T, n), size, bits, minval, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(control, toTest) {
+			t.Fatalf("%T: %d bit digit sort of %d failed", T(0), bits, n)
+		}
+	}
+}
+
+func TestSortWide(t *testing.T) {
+	if testing.Short() {
+		t.Skip("large sort")
+	}
+	// One partition large enough for wide digits, with the rest of the slice spread over the other top bytes
+	testSortWide[uint64](t, wideRadixMin)
+	testSortWide[uint32](t, wideRadixMin)
+	testSortWide[int64](t, wideRadixMin)
+}
+
+func testSortWide[T Integer](t *testing.T, bucket int) {
+	size, _ := internal.Detect[T]()
+	shift := size - radix
+	x := make([]T, bucket+bucket/4)
+	for i := range x {
+		x[i] = T(rand.Uint64() >> (64 - shift))
+		if i >= bucket {
+			x[i] |= T(1+rand.Intn(127)) << shift
+		}
+	}
+	rand.Shuffle(len(x), func(i, j int) { x[i], x[j] = x[j], x[i] })
+	control := slices.Clone(x)
+	slices.Sort(control)
+	for _, parallelism := range []int{1, 3} {
+		var stats Stats
+		test := NewSorterWith[T](WithParallelism(parallelism), WithObserver(func(s Stats) { stats = s }))
+		toTest := slices.Clone(x)
+		test.Sort(toTest)
+		if !slices.Equal(control, toTest) {
+			t.Fatalf("%T: sort of %d with parallelism %d failed", T(0), len(x), parallelism)
+		}
+		if passes := int(size / radix); stats.Passes != passes || stats.SkippedPasses != 0 {
+			t.Errorf("%T: sort of %d with parallelism %d took %d passes, skipped %d, expected %d byte passes",
+				T(0), len(x), parallelism, stats.Passes, stats.SkippedPasses, passes)
+		}
+	}
+}
+
+func TestStagedScatter(t *testing.T) {
+	testStagedScatter[int8](t, internal.RandInteger[int8](), radix)
+	testStagedScatter[int16](t, internal.RandInteger[int16](), wideRadix)
+	testStagedScatter[uint32](t, internal.RandInteger[uint32](), radix)
+	testStagedScatter[int32](t, internal.RandInteger[int32](), wideRadix)
+	testStagedScatter[int64](t, internal.RandInteger[int64](), wideRadix)
+	testStagedScatter[uint64](t, internal.RandInteger[uint64](), radix)
+}
+
+func testStagedScatter[T Integer](t *testing.T, gen func() T, bits uint) {
+	size, minval := internal.Detect[T]()
+	d := newDigits(size, bits, minval != 0)
+	stage := newStager[T](d)
+	defer stage.release()
+	for _, n := range []int{0, 1, 100, 5000} {
+		from := make([]T, n)
+		internal.FillSlice(from, gen)
+		for shift := uint(0); shift < size; shift += bits {
+			counts := make([]int, d.buckets())
+			for _, elem := range from {
+				counts[d.key(uint64(elem), shift)]++
+			}
+			var watermark int
+			for key, count := range counts {
+				counts[key] = watermark
+				watermark += count
+			}
+			control := make([]T, n)
+			offset := slices.Clone(counts)
+			for _, elem := range from {
+				key := d.key(uint64(elem), shift)
+				control[offset[key]] = elem
+				offset[key]++
+			}
+			to := make([]T, n)
+			stage.scatter(from, to, counts, d, shift, 0)
+			if !slices.Equal(control, to) || !slices.Equal(offset, counts) {
+				t.Fatalf("%T: staged scatter of %d by %d bits at %d failed", T(0), n, bits, shift)
+			}
+		}
+	}
+}
+
+func TestUseStagedScatter(t *testing.T) {
+	for _, test := range []struct {
+		d        digits
+		n        int
+		expected bool
+	}{
+		{newDigits(64, radix, false), stagedScatterMin/8 - 1, false},
+		{newDigits(64, radix, false), stagedScatterMin / 8, true},
+		{newDigits(64, wideRadix, true), stagedScatterMin / 8, true},
+		{newDigits(32, widestRadix, false), stagedScatterMin, false},
+	} {
+		if staged := useStagedScatter(test.d, test.n); staged != test.expected {
+			t.Errorf("useStagedScatter(%d bits, %d) = %v, expected %v", test.d.bits, test.n, staged, test.expected)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package zermelo
+
+import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// The floats package sorts the bits of floats as unsigned integers with these, see sortFloatBitsBYOB.
+func init() {
+	internal.SortFloatBits32 = sortFloatBitsBYOB[uint32]
+	internal.SortFloatBits64 = sortFloatBitsBYOB[uint64]
+}
+
+// sortFloatBitsBYOB is SortBYOB for the bits of floats, sorting them in float order. x must not hold NaNs.
+// A non-zero allocBytes is recorded as the allocation of the buffer.
+func sortFloatBitsBYOB[U Unsigned](x, buffer []U, allocBytes int) {
+	if len(x) >= 2 {
+		size, _ := internal.Detect[U]()
+		// The buffer was taken before knowing if it is needed, so it is recorded however x is sorted
+		var bounds [maxMergeRuns + 1]int
+		if runs := findRuns(x, &bounds, size); runs != 0 {
+			ob := startObserving(context.Background(), nil, Merge, len(x), size)
+			if allocBytes > 0 {
+				ob.alloc(allocBytes)
+			}
+			mergeRuns(x, buffer[:len(x)], bounds[:runs+1], size)
+			ob.finish(0, nil)
+			return
+		}
+		ob := startObserving(context.Background(), nil, Radix, len(x), size)
+		if allocBytes > 0 {
+			ob.alloc(allocBytes)
+		}
+		_ = floatBitsSort(x, buffer, size, 1, ob.passFunc())
+		ob.finish(radixPasses(size, len(x)), nil)
+	}
+}
+
+// sortFloatBits is floatBitsSort for a slice of uint32 or uint64.
+func sortFloatBits[T Integer](x, buffer []T, size uint, parallelism int, pass passFunc) error {
+	switch x := any(x).(type) {
+	case []uint32:
+		return floatBitsSort(x, any(buffer).([]uint32), size, parallelism, pass)
+	case []uint64:
+		return floatBitsSort(x, any(buffer).([]uint64), size, parallelism, pass)
+	}
+	panic("zermelo: float bits must be uint32 or uint64")
+}
+
+// floatBitsSort is radixSort for the bits of floats, which are size bits wide, sorting them in float order.
+// Floats are mapped to unsigned integers in the same order by flipping all bits of negatives, and only the sign bit
+// of positives. This is fused into the first radix pass, or the partition of a slice too large for the CPU cache,
+// and undone in the last pass, or that of each partition. Up to parallelism goroutines are used, as by
+// sortBYOBParallel. Slices made of a few runs in float order are left to sortRuns.
+// x is sorted before pass is called for the last pass, so an error returned then is ignored.
+func floatBitsSort[U Unsigned](x, buffer []U, size uint, parallelism int, pass passFunc) error {
+	workers := min(parallelism, len(x)/parallelMinChunk)
+	switch {
+	case useHybrid(size, len(x)):
+		return hybridSort(x, buffer, size, 0, size, shortSort[U]{}, max(workers, 1), pass)
+	case workers > 1:
+		return parallelRadixSort(x, buffer, size, 0, size, workers, pass)
+	}
+	return floatRadixSort(x, buffer, size, false, pass)
+}
+
+// floatRadixSort is lsdRadixSort for the bits of floats, which are size bits wide, flipping them in the first pass
+// and unflipping them in the last, see floatBitsSort. If flipped, x holds them already flipped, as do the buckets of
+// hybridSort, and they are only unflipped.
+func floatRadixSort[T Integer](x, buffer []T, size uint, flipped bool, pass passFunc) error {
+	var counts [maxPasses][256]int
+	passes := int(size / radix)
+	var sorted bool
+	if flipped {
+		sorted = histograms(x, counts[:passes], 0)
+	} else {
+		sorted = flippedHistograms(x, counts[:passes], size)
+	}
+	if sorted { // Short-circuit sorted
+		if flipped {
+			unflipFloatBits(x, x, size)
+		}
+		return nil
+	}
+	var moving [maxPasses]int
+	return scatterPasses(x, buffer, movingPasses(&moving, passes, len(x), func(p int) []int {
+		return counts[p][:]
+	}), size, pass, func(from, to []T, p int, first, last bool) {
+		offset := &counts[p]
+		bucketOffsets(offset, false)
+		floatScatter(from, to, offset, uint(p)*radix, size, first && !flipped, last)
+	})
+}
+
+// floatScatter is the scatter loop of floatRadixSort, moving each element of from to to by its byte shifted right
+// by shift bits. Elements of from are flipped as they are keyed if flip, and unflipped as they are stored if unflip,
+// see flipFloat. Both at once keys the elements by their flipped bits, leaving them as they are.
+func floatScatter[T Integer](from, to []T, offset *[256]int, shift, size uint, flip, unflip bool) {
+	switch {
+	case flip && unflip:
+		for _, elem := range from {
+			key := uint8(flipFloat(uint64(elem), size) >> shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	case flip:
+		for _, elem := range from {
+			elem = T(flipFloat(uint64(elem), size))
+			key := uint8(elem >> shift)
+			to[offset[key]] = elem
+			offset[key]++
+		}
+	case unflip:
+		for _, elem := range from {
+			key := uint8(elem >> shift)
+			to[offset[key]] = T(unflipFloat(uint64(elem), size))
+			offset[key]++
+		}
+	default:
+		if !specializedScatter(from, to, offset, shift) {
+			for _, elem := range from {
+				key := uint8(elem >> shift)
+				to[offset[key]] = elem
+				offset[key]++
+			}
+		}
+	}
+}
+
+// flippedHistograms is histograms for the bits of floats, counting the bytes of each element after flipFloat.
+func flippedHistograms[T Integer](x []T, counts [][256]int, size uint) bool {
+	var (
+		prev   T
+		sorted = true
+	)
+	switch len(counts) {
+	case 4:
+		c0, c1, c2, c3 := &counts[0], &counts[1], &counts[2], &counts[3]
+		for _, elem := range x {
+			elem = T(flipFloat(uint64(elem), size))
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	default:
+		c0, c1, c2, c3 := &counts[0], &counts[1], &counts[2], &counts[3]
+		c4, c5, c6, c7 := &counts[4], &counts[5], &counts[6], &counts[7]
+		for _, elem := range x {
+			elem = T(flipFloat(uint64(elem), size))
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			c4[uint8(u>>32)]++
+			c5[uint8(u>>40)]++
+			c6[uint8(u>>48)]++
+			c7[uint8(u>>56)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	}
+	return sorted
+}
+
+// flipFloat maps the bits of a float, which are size bits wide, to an unsigned integer in the same order.
+// Negative floats have all bits flipped, so larger magnitudes are smaller, and positives only the sign bit.
+func flipFloat[U Unsigned](bits U, size uint) U {
+	return bits ^ (-(bits >> (size - 1)) | U(1)<<(size-1))
+}
+
+// floatKey returns elem, or if floatBits is not zero, flipFloat of elem as float bits that wide, so that its order as
+// an integer is its order as a float. The branch is the same for every element of a sort, so it is cheap to predict.
+func floatKey[T Integer](elem T, floatBits uint) T {
+	if floatBits == 0 {
+		return elem
+	}
+	return T(flipFloat(uint64(elem), floatBits))
+}
+
+// unflipFloat reverses flipFloat.
+func unflipFloat[U Unsigned](flipped U, size uint) U {
+	return flipped ^ ((flipped >> (size - 1)) - 1 | U(1)<<(size-1))
+}
+
+// flipFloatBits sets each element of to to flipFloat of that of from, which are float bits that are size bits wide.
+func flipFloatBits[T Integer](to, from []T, size uint) {
+	for i, elem := range from {
+		to[i] = T(flipFloat(uint64(elem), size))
+	}
+}
+
+// unflipFloatBits sets each element of to to unflipFloat of that of from, which are float bits that are size bits
+// wide.
+func unflipFloatBits[T Integer](to, from []T, size uint) {
+	for i, elem := range from {
+		to[i] = T(unflipFloat(uint64(elem), size))
+	}
+}
@@ -0,0 +1,138 @@
+package zermelo
+
+import (
+	"errors"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestFlipFloat(t *testing.T) {
+	values := []float64{math.Inf(-1), -math.MaxFloat64, -1, -math.SmallestNonzeroFloat64, math.Copysign(0, -1), 0,
+		math.SmallestNonzeroFloat64, 1, math.MaxFloat64, math.Inf(1)}
+	var prev uint64
+	for i, v := range values {
+		bits := math.Float64bits(v)
+		flipped := flipFloat(bits, 64)
+		if i > 0 && flipped <= prev {
+			t.Fatal("flipped bits out of order at", v)
+		}
+		if unflipFloat(flipped, 64) != bits {
+			t.Fatal("unflip did not reverse flip of", v)
+		}
+		prev = flipped
+	}
+}
+
+func TestFloatBitsSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gens := map[string]func() float64{
+		"random": func() float64 { return math.Float64frombits(rng.Uint64()&^(0x7ff<<52)) * 1e10 },
+		"normal": rng.NormFloat64,
+		"narrow": func() float64 { return float64(rng.Intn(256) - 128) }, // one or two passes
+		"one":    func() float64 { return -1 },
+	}
+	sizes := []int{2, testSize, 3 * parallelMinChunk}
+	if !testing.Short() {
+		sizes = append(sizes, hybridMin/8) // partitioned
+	}
+	for name, gen := range gens {
+		for _, parallelism := range []int{1, 3} {
+			for _, n := range sizes {
+				x := make([]uint64, n)
+				for i := range x {
+					x[i] = math.Float64bits(gen())
+				}
+				control := slices.Clone(x)
+				slices.SortFunc(control, func(a, b uint64) int {
+					return compareFloats(math.Float64frombits(a), math.Float64frombits(b))
+				})
+				if err := floatBitsSort(x, make([]uint64, n), 64, parallelism, nil); err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(control, x) {
+					t.Fatalf("%s: float bits sort of %d with parallelism %d failed", name, n, parallelism)
+				}
+			}
+		}
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func TestFloatBitsSortCancel(t *testing.T) {
+	stop := errors.New("stop")
+	for at := 1; at <= 4; at++ {
+		x := make([]uint32, testSize)
+		internal.FillSlice(x, func() uint32 { return math.Float32bits(float32(rand.NormFloat64())) })
+		control := slices.Clone(x)
+		slices.Sort(control)
+		err := floatBitsSort(x, make([]uint32, len(x)), 32, 1, func(done int) error {
+			if done == at {
+				return stop
+			}
+			return nil
+		})
+		if at == 4 { // Stopping after the last pass is too late, x is sorted
+			if err != nil || !slices.IsSortedFunc(x, func(a, b uint32) int {
+				return compareFloats(float64(math.Float32frombits(a)), float64(math.Float32frombits(b)))
+			}) {
+				t.Fatal("sort not finished when stopped after the last pass", err)
+			}
+		} else if !errors.Is(err, stop) {
+			t.Fatal("sort not stopped at pass", at, err)
+		}
+		slices.Sort(x)
+		if !slices.Equal(control, x) {
+			t.Fatal("bits changed when stopped at pass", at)
+		}
+	}
+}
+
+func TestFloatBitsSortCancelLarge(t *testing.T) {
+	stop := errors.New("stop")
+	sizes := []int{3 * parallelMinChunk}
+	if !testing.Short() {
+		sizes = append(sizes, hybridMin/8) // partitioned
+	}
+	for _, n := range sizes {
+		for _, parallelism := range []int{1, 3} {
+			for _, at := range []int{1, 2, 5, 8} {
+				x := make([]uint64, n)
+				internal.FillSlice(x, func() uint64 { return math.Float64bits(rand.NormFloat64()) })
+				control := slices.Clone(x)
+				slices.Sort(control)
+				err := floatBitsSort(x, make([]uint64, len(x)), 64, parallelism, func(done int) error {
+					if done == at {
+						return stop
+					}
+					return nil
+				})
+				if at == 8 { // Stopping after the last pass is too late, x is sorted
+					if err != nil || !slices.IsSortedFunc(x, func(a, b uint64) int {
+						return compareFloats(math.Float64frombits(a), math.Float64frombits(b))
+					}) {
+						t.Fatalf("%d with parallelism %d: sort not finished when stopped after the last pass: %v",
+							n, parallelism, err)
+					}
+				} else if !errors.Is(err, stop) {
+					t.Fatalf("%d with parallelism %d: sort not stopped at pass %d: %v", n, parallelism, at, err)
+				}
+				slices.Sort(x)
+				if !slices.Equal(control, x) {
+					t.Fatalf("%d with parallelism %d: bits changed when stopped at pass %d", n, parallelism, at)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package floats
+
+import (
+	"context"
+	"errors"
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSorterCancel(t *testing.T) {
+	testSorterCancel[float32](t, randFloat32(true), 2)
+	testSorterCancel[float64](t, randFloat64(true), 1)
+	testSorterCancel[float64](t, randFloat64(true), 5)
+}
+
+// testSorterCancel checks that cancelling after stopAfter passes leaves x a permutation of its elements.
+func testSorterCancel[F Float](t *testing.T, gen func() F, stopAfter int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	test := NewFloatSorterWith[F](zermelo.WithCutoff(0), zermelo.WithProgress(func(done, total int) {
+		if done == stopAfter {
+			cancel()
+		}
+	})).(zermelo.ContextSorter[F])
+	x := make([]F, testSize)
+	internal.FillSlice(x, gen)
+	control := slices.Clone(x)
+	sortSort(control)
+	if err := test.SortContext(ctx, x); !errors.Is(err, context.Canceled) {
+		t.Fatalf("%T: sort not cancelled after %d passes: %v", F(0), stopAfter, err)
+	}
+	sortSort(x)
+	if !floatSlicesEqual(x, control) {
+		t.Fatalf("%T: cancelled after %d passes, x is not a permutation", F(0), stopAfter)
+	}
+	if err := test.SortContext(context.Background(), x); err != nil || !floatSlicesEqual(x, control) {
+		t.Fatalf("%T: sort after cancel failed: %v", F(0), err)
+	}
+}
@@ -0,0 +1,39 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// cutoffs holds the process-wide comparison sort cutoffs used by SortFloats and by Sorters without a cutoff option.
+// Only the 32 and 64 bit entries are used.
+var cutoffs = internal.NewCutoffs(0, 0, compSortCutoffFloat32, compSortCutoffFloat64)
+
+// Cutoff returns the process-wide comparison sort cutoff for F. Slices shorter than this are sorted with
+// slices.Sort instead of radix sort.
+func Cutoff[F Float]() int {
+	return cutoffs.Get(floatSize[F]())
+}
+
+// SetCutoff sets the process-wide comparison sort cutoff for all float types of the same size as F.
+// This affects SortFloats and all Sorters not created with zermelo.WithCutoff, including those already created.
+func SetCutoff[F Float](cutoff int) {
+	cutoffs.Set(floatSize[F](), cutoff)
+}
+
+// Calibrate measures radix sort against comparison sort on this machine for 32 and 64 bit floats,
+// and sets the process-wide cutoffs to the measured crossover points. It takes a fraction of a second,
+// and is intended to be called once at startup.
+func Calibrate() {
+	rng32 := internal.RandInteger[int32]()
+	SetCutoff[float32](internal.Crossover(func() float32 { return float32(rng32()) }, SortFloatsBYOB[float32]))
+	rng64 := internal.RandInteger[int64]()
+	SetCutoff[float64](internal.Crossover(func() float64 { return float64(rng64()) }, SortFloatsBYOB[float64]))
+}
+
+// floatSize returns the size of F in bits
+func floatSize[F Float]() uint {
+	if isFloat32[F]() {
+		return 32
+	}
+	return 64
+}
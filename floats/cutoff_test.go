@@ -0,0 +1,33 @@
+package floats
+
+import (
+	"testing"
+)
+
+func TestSetCutoff(t *testing.T) {
+	defer SetCutoff[float32](compSortCutoffFloat32)
+	if Cutoff[float32]() != compSortCutoffFloat32 || Cutoff[float64]() != compSortCutoffFloat64 {
+		t.Fatal("wrong default cutoffs", Cutoff[float32](), Cutoff[float64]())
+	}
+	SetCutoff[float32](0)
+	if Cutoff[float32]() != 0 || Cutoff[float64]() != compSortCutoffFloat64 {
+		t.Fatal("wrong cutoffs after set", Cutoff[float32](), Cutoff[float64]())
+	}
+	testSort[float32](t, randFloat32(false), false, false)
+	if !testSorter[float32](randFloat32(true), true, true) {
+		t.Fatal("failed float32 zero cutoff")
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	defer func() {
+		SetCutoff[float32](compSortCutoffFloat32)
+		SetCutoff[float64](compSortCutoffFloat64)
+	}()
+	Calibrate()
+	t.Logf("cutoffs: float32 %d, float64 %d", Cutoff[float32](), Cutoff[float64]())
+	if Cutoff[float32]() <= 0 || Cutoff[float64]() <= 0 {
+		t.Fatal("cutoffs not calibrated")
+	}
+	testSort[float64](t, randFloat64(false), false, false)
+}
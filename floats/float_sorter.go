@@ -1,7 +1,9 @@
 package floats
 
 import (
+	"context"
 	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
 	"slices"
 )
 
@@ -11,26 +13,70 @@ type cutoffSorter[F Float] interface {
 	withCutoff(int) cutoffSorter[F]
 }
 
+// wrappedSorter is the uint sorter used by a floatSorter.
+type wrappedSorter[U zermelo.Unsigned] interface {
+	zermelo.BufferedSorter[U]
+	zermelo.ContextSorter[U]
+}
+
 type floatSorter[F Float, U zermelo.Unsigned] struct {
-	uintSorter     zermelo.Sorter[U]
-	compSortCutoff int
-	topBit         U
+	uintSorter     wrappedSorter[U]
+	compSortCutoff int // internal.DefaultCutoff to use the process-wide cutoff
+	descending     bool
+	nansLast       bool
+	progress       func(done, total int)
+	observer       zermelo.Observer
+	small          zermelo.SmallSorter[F] // nil for slices.Sort
+	size           uint
 }
 
 func (s *floatSorter[F, U]) Sort(x []F) {
-	x = sortNaNs(x)
-	if len(x) < 2 {
-		return
+	_ = s.SortContext(context.Background(), x)
+}
+
+func (s *floatSorter[F, U]) SortContext(ctx context.Context, x []F) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.nansLast {
+		x = sortNaNsLast(x)
+	} else {
+		x = sortNaNs(x)
 	}
-	if len(x) < s.compSortCutoff {
-		slices.Sort(x)
-		return
+	cutoff := s.compSortCutoff
+	if cutoff == internal.DefaultCutoff {
+		cutoff = cutoffs.Get(s.size)
 	}
+	if len(x) < max(cutoff, 2) {
+		comparisonSort(ctx, x, s.small, s.observer)
+		if s.progress != nil {
+			s.progress(1, 1)
+		}
+	} else {
+		if err := s.uintSorter.SortContext(ctx, unsafeSliceConvert[F, U](x)); err != nil {
+			return err
+		}
+	}
+	if s.descending {
+		slices.Reverse(x)
+	}
+	return nil
+}
 
-	y := unsafeSliceConvert[F, U](x)
-	floatFlip[U](y, s.topBit)
-	s.uintSorter.Sort(y)
-	floatUnflip[U](y, s.topBit)
+func (s *floatSorter[F, U]) Reserve(n int) {
+	s.uintSorter.Reserve(n)
+}
+
+func (s *floatSorter[F, U]) Shrink() {
+	s.uintSorter.Shrink()
+}
+
+func (s *floatSorter[F, U]) Release() {
+	s.uintSorter.Release()
+}
+
+func (s *floatSorter[F, U]) BufferBytes() int {
+	return s.uintSorter.BufferBytes()
 }
 
 func (s *floatSorter[F, U]) withCutoff(cutoff int) cutoffSorter[F] {
@@ -41,22 +87,58 @@ func (s *floatSorter[F, U]) withCutoff(cutoff int) cutoffSorter[F] {
 // NewFloatSorter creates a new Sorter for float slices that will use radix sort on large slices and reuses buffers.
 // The first sort creates a buffer the same size as the slice being sorted and keeps it for future use.
 // Later sorts may grow this buffer as needed. The FloatSorter returned is not thread safe.
+// The Sorter returned is a zermelo.BufferedSorter, allowing the buffer to be reserved ahead of time or released,
+// and a zermelo.ContextSorter, allowing sorts to be cancelled.
 // Using this sorter can be much faster than repeat calls to SortFloats.
 func NewFloatSorter[F Float]() zermelo.Sorter[F] {
 	return newFloatSorter[F]()
 }
 
-func newFloatSorter[F Float]() cutoffSorter[F] {
+// NewFloatSorterWith creates a new Sorter like NewFloatSorter, configured by the given options.
+// All zermelo options apply, as does WithNaNsLast.
+func NewFloatSorterWith[F Float](opts ...zermelo.Option) zermelo.Sorter[F] {
+	return newFloatSorter[F](opts...)
+}
+
+func newFloatSorter[F Float](opts ...zermelo.Option) cutoffSorter[F] {
+	o := internal.ApplyOptions(opts)
+	observer, _ := o.Observer.(zermelo.Observer)
+	small, _ := o.SmallSorter.(zermelo.SmallSorter[F])
+	// Buffer options are passed on to the uint sorter. The float sorter makes the comparison sort decision
+	// and handles order, so the uint sorter always uses radix sort and sorts the float bits ascending.
+	uintOpts := append(slices.Clip(opts), zermelo.WithCutoff(0), func(o *internal.Options) {
+		o.Descending = false
+		o.FloatBits = true
+	})
 	if isFloat32[F]() {
 		return &floatSorter[F, uint32]{
-			uintSorter:     zermelo.NewSorter[uint32](),
-			compSortCutoff: compSortCutoffFloat32,
-			topBit:         uint32(1) << 31,
+			uintSorter:     zermelo.NewSorterWith[uint32](uintOpts...).(wrappedSorter[uint32]),
+			compSortCutoff: o.Cutoff,
+			descending:     o.Descending,
+			nansLast:       o.NaNsLast,
+			progress:       o.Progress,
+			observer:       observer,
+			small:          small,
+			size:           32,
 		}
 	}
 	return &floatSorter[F, uint64]{
-		uintSorter:     zermelo.NewSorter[uint64](),
-		compSortCutoff: compSortCutoffFloat64,
-		topBit:         uint64(1) << 63,
+		uintSorter:     zermelo.NewSorterWith[uint64](uintOpts...).(wrappedSorter[uint64]),
+		compSortCutoff: o.Cutoff,
+		descending:     o.Descending,
+		nansLast:       o.NaNsLast,
+		progress:       o.Progress,
+		observer:       observer,
+		small:          small,
+		size:           64,
 	}
 }
+
+// NewConcurrentFloatSorter creates a new Sorter like NewFloatSorterWith that is safe for concurrent use.
+// Each call to Sort borrows a Sorter, and its buffer, from a pool kept per P.
+// Pooled Sorters are freed by the garbage collector when unused.
+func NewConcurrentFloatSorter[F Float](opts ...zermelo.Option) zermelo.Sorter[F] {
+	return internal.NewConcurrentSorter[F](func() internal.Sorter[F] {
+		return newFloatSorter[F](opts...)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/shawnsmithdev/zermelo/v2/internal"
 	"math"
 	"slices"
+	"sync"
 	"testing"
 )
 
@@ -23,6 +24,18 @@ func TestSorter(t *testing.T) {
 	}
 }
 
+func TestSorterNarrowNegatives(t *testing.T) {
+	// Bits of these are within a narrow span, but not ordered as integers
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = math.Float64frombits(math.Float64bits(-1) + uint64(i*7919%100))
+	}
+	NewFloatSorter[float64]().Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("narrow negatives not sorted")
+	}
+}
+
 func TestSorterNaNs(t *testing.T) {
 	if !testSorter[float32](randFloat32(true), true, false) {
 		t.Fatal("failed float32 nans")
@@ -80,3 +93,51 @@ func testSorter[F Float](gen func() F, nans bool, cutoff bool) bool {
 	}
 	return true
 }
+
+func TestBufferedSorter(t *testing.T) {
+	test := newFloatSorter[float64]().withCutoff(0).(zermelo.BufferedSorter[float64])
+	test.Reserve(10)
+	if test.BufferBytes() != 80 {
+		t.Fatal("wrong reserved size", test.BufferBytes())
+	}
+	x := make([]float64, 20)
+	internal.FillSlice(x, randFloat64(false))
+	test.Sort(x)
+	if !slices.IsSorted(x) || test.BufferBytes() < 160 {
+		t.Fatal("sort failed", x, test.BufferBytes())
+	}
+	test.Shrink()
+	if test.BufferBytes() != 160 {
+		t.Fatal("shrink did not reduce buffer", test.BufferBytes())
+	}
+	test.Release()
+	if test.BufferBytes() != 0 {
+		t.Fatal("release kept buffer", test.BufferBytes())
+	}
+}
+
+func TestConcurrentSorter(t *testing.T) {
+	test := NewConcurrentFloatSorter[float32](WithNaNsLast())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := randFloat32(true)
+			for n := 0; n < testSize; n += 7 {
+				x := make([]float32, n)
+				internal.FillSlice(x, rng)
+				test.Sort(x)
+				nans := slices.IndexFunc(x, isNaN[float32])
+				if nans < 0 {
+					nans = n
+				}
+				if !slices.IsSorted(x[:nans]) || slices.ContainsFunc(x[nans:], func(f float32) bool { return !isNaN(f) }) {
+					t.Error("concurrent sort failed for length", n)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,9 +1,10 @@
 package floats
 
 import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
 	"math"
 	"runtime"
-	"slices"
 )
 
 const (
@@ -16,18 +17,25 @@ type Float interface {
 	~float32 | ~float64
 }
 
-// SortFloats sorts float slices. If the slice is large enough, radix sort is used by allocating a new buffer.
+// SortFloats sorts float slices. If the slice is large enough, radix sort is used with a buffer borrowed from a pool,
+// see zermelo.SetBufferPooling.
+// Slices shorter than the cutoff for F, see SetCutoff, are sorted with slices.Sort.
 func SortFloats[F Float](x []F) {
 	x = sortNaNs(x)
 	if len(x) < 2 {
 		return
 	}
-	is32 := isFloat32[F]()
-	if len(x) < compSortCutoffFloat32 || (!is32 && len(x) < compSortCutoffFloat64) {
-		slices.Sort(x)
+	if len(x) < Cutoff[F]() {
+		comparisonSort(context.Background(), x, nil, nil)
 		return
 	}
-	sortFloatsBYOB(x, make([]F, len(x)), is32)
+	buf, allocated := internal.GetBuffer[F](len(x))
+	allocBytes := 0
+	if allocated {
+		allocBytes = len(*buf) * int(floatSize[F]()/8)
+	}
+	sortFloatsBYOB(x, *buf, allocBytes)
+	internal.PutBuffer(buf)
 }
 
 // SortFloatsBYOB sorts float slices with radix sort using the provided buffer.
@@ -35,15 +43,16 @@ func SortFloats[F Float](x []F) {
 func SortFloatsBYOB[F Float](x, buffer []F) {
 	x = sortNaNs(x)
 	if len(x) >= 2 {
-		sortFloatsBYOB(x, buffer, isFloat32[F]())
+		sortFloatsBYOB(x, buffer, 0)
 	}
 }
 
-func sortFloatsBYOB[F Float](x, buf []F, is32 bool) {
-	if is32 {
-		unsafeFlipSortFlip[F, uint32](x, buf, 32)
+// sortFloatsBYOB radix sorts x with buf, reporting allocBytes to observers as the size of buf if it is not zero.
+func sortFloatsBYOB[F Float](x, buf []F, allocBytes int) {
+	if isFloat32[F]() {
+		unsafeSortBits[F](x, buf, allocBytes, internal.SortFloatBits32)
 	} else {
-		unsafeFlipSortFlip[F, uint64](x, buf, 64)
+		unsafeSortBits[F](x, buf, allocBytes, internal.SortFloatBits64)
 	}
 	runtime.KeepAlive(buf) // avoid gc as buf is never used directly
 }
@@ -68,3 +77,16 @@ func sortNaNs[F Float](x []F) []F {
 	}
 	return x[nans:]
 }
+
+// sortNaNsLast put nans at the end, returning a slice of x excluding those nans
+func sortNaNsLast[F Float](x []F) []F {
+	end := len(x)
+	for idx := len(x) - 1; idx >= 0; idx-- {
+		if val := x[idx]; isNaN(val) {
+			end--
+			x[idx] = x[end]
+			x[end] = val
+		}
+	}
+	return x[:end]
+}
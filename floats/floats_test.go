@@ -101,3 +101,15 @@ func floatSlicesEqual[F Float](x, y []F) bool {
 	}
 	return true
 }
+
+func TestSortPooled(t *testing.T) {
+	x := make([]float64, testSize)
+	rng := randFloat64(true)
+	allocs := testing.AllocsPerRun(10, func() {
+		internal.FillSlice(x, rng)
+		SortFloats(x)
+	})
+	if allocs != 0 {
+		t.Fatal("pooled sort allocated", allocs)
+	}
+}
@@ -5,35 +5,12 @@ import (
 	"unsafe"
 )
 
-// unsafeFlipSortFlip converts float slices to unsigned, flips some bits to allow sorting, sorts and unflips.
-// F and U must be the same bit size, and len(buf) must be >= len(x)
+// unsafeSortBits converts float slices to unsigned and radix sorts their bits in float order.
+// F and U must be the same bit size, and len(buf) must be >= len(x). allocBytes is reported to observers as the
+// size of the buffer, if it was allocated for this sort.
 // This will not work if NaNs are present in x. Remove them first.
-func unsafeFlipSortFlip[F Float, U zermelo.Unsigned](x, b []F, size uint) {
-	xu := unsafeSliceConvert[F, U](x)
-	bu := unsafeSliceConvert[F, U](b)
-	floatFlip[U](xu, U(1)<<(size-1))
-	zermelo.SortBYOB(xu, bu)
-	floatUnflip[U](xu, U(1)<<(size-1))
-}
-
-func floatFlip[U zermelo.Unsigned](y []U, topBit U) {
-	for idx, val := range y {
-		if val&topBit == topBit {
-			y[idx] = val ^ (^U(0))
-		} else {
-			y[idx] = val ^ topBit
-		}
-	}
-}
-
-func floatUnflip[U zermelo.Unsigned](y []U, topBit U) {
-	for idx, val := range y {
-		if val&topBit == topBit {
-			y[idx] = val ^ topBit
-		} else {
-			y[idx] = val ^ (^U(0))
-		}
-	}
+func unsafeSortBits[F Float, U zermelo.Unsigned](x, b []F, allocBytes int, sortBits func(x, buffer []U, allocBytes int)) {
+	sortBits(unsafeSliceConvert[F, U](x), unsafeSliceConvert[F, U](b), allocBytes)
 }
 
 // unsafeSliceConvert takes a slice of one type and returns a slice of another type using the same memory
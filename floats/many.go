@@ -0,0 +1,14 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// SortManyFloats sorts each slice in xs, using up to workers goroutines, or GOMAXPROCS if workers is not positive.
+// Each goroutine reuses the buffer of its own Sorter, and slices below the cutoff are sorted with slices.Sort.
+// The slices in xs must not overlap.
+func SortManyFloats[F Float](xs [][]F, workers int) {
+	internal.SortMany(xs, workers, func() internal.Sorter[F] {
+		return NewFloatSorter[F]()
+	})
+}
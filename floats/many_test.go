@@ -0,0 +1,29 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortManyFloats(t *testing.T) {
+	testSortManyFloats[float32](t, randFloat32(true), 0)
+	testSortManyFloats[float64](t, randFloat64(true), 4)
+}
+
+func testSortManyFloats[F Float](t *testing.T, rng func() F, workers int) {
+	xs := make([][]F, 50)
+	controls := make([][]F, len(xs))
+	for i := range xs {
+		xs[i] = make([]F, i*testSize/len(xs))
+		internal.FillSlice(xs[i], rng)
+		controls[i] = slices.Clone(xs[i])
+		sortSort(controls[i])
+	}
+	SortManyFloats(xs, workers)
+	for i := range xs {
+		if !floatSlicesEqual(xs[i], controls[i]) {
+			t.Fatalf("%T: workers=%d, slice %d not sorted", F(0), workers, i)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package floats
+
+import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"runtime/trace"
+	"slices"
+	"time"
+)
+
+// comparisonSort sorts x with small, or slices.Sort if it is nil, reporting it to local and process-wide observers,
+// see zermelo.Observer. Radix sorts are reported by the zermelo package.
+func comparisonSort[F Float](ctx context.Context, x []F, small zermelo.SmallSorter[F], local zermelo.Observer) {
+	if small == nil {
+		small = slices.Sort[[]F]
+	}
+	global, _ := internal.LoadObserver().(zermelo.Observer)
+	if local == nil && global == nil && !trace.IsEnabled() {
+		small(x)
+		return
+	}
+	region := trace.StartRegion(ctx, "zermelo."+zermelo.Comparison.String())
+	start := time.Now()
+	small(x)
+	stats := zermelo.Stats{Algorithm: zermelo.Comparison, Len: len(x), Size: floatSize[F](), Elapsed: time.Since(start)}
+	region.End()
+	if local != nil {
+		local(stats)
+	}
+	if global != nil {
+		global(stats)
+	}
+}
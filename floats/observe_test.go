@@ -0,0 +1,57 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"testing"
+)
+
+func TestWithObserver(t *testing.T) {
+	var stats []zermelo.Stats
+	test := NewFloatSorterWith[float64](zermelo.WithObserver(func(s zermelo.Stats) {
+		stats = append(stats, s)
+	}))
+	x := make([]float64, testSize)
+	internal.FillSlice(x, randFloat64(false))
+	test.Sort(x[:10])
+	test.Sort(x)
+	if len(stats) != 2 || stats[0].Algorithm != zermelo.Comparison || stats[0].Len != 10 || stats[0].Size != 64 ||
+		stats[1].Algorithm != zermelo.Radix || stats[1].Len != testSize || stats[1].Allocs != 1 {
+		t.Fatal("wrong observations", stats)
+	}
+}
+
+func TestSetObserver(t *testing.T) {
+	defer zermelo.SetObserver(nil)
+	var stats []zermelo.Stats
+	zermelo.SetObserver(func(s zermelo.Stats) {
+		stats = append(stats, s)
+	})
+	x := make([]float32, testSize)
+	internal.FillSlice(x, randFloat32(false))
+	SortFloats(x[:10])
+	SortFloats(x)
+	if len(stats) != 2 || stats[0].Algorithm != zermelo.Comparison || stats[0].Size != 32 ||
+		stats[1].Algorithm != zermelo.Radix || stats[1].Passes != 4 {
+		t.Fatal("wrong global observations", stats)
+	}
+}
+
+func TestSortFloatsAllocs(t *testing.T) {
+	zermelo.SetBufferPooling(false)
+	defer zermelo.SetBufferPooling(true)
+	defer zermelo.SetObserver(nil)
+	var stats []zermelo.Stats
+	zermelo.SetObserver(func(s zermelo.Stats) {
+		stats = append(stats, s)
+	})
+	x := make([]float64, testSize)
+	internal.FillSlice(x, randFloat64(false))
+	SortFloats(x)
+	SortFloatsBYOB(x, make([]float64, testSize))
+	SortFloats(x) // already sorted, but the buffer is taken first
+	if len(stats) != 3 || stats[0].Allocs != 1 || stats[0].AllocBytes != 8*testSize || stats[1].Allocs != 0 ||
+		stats[1].Algorithm != zermelo.Merge || stats[2].Algorithm != zermelo.Merge || stats[2].Allocs != 1 {
+		t.Fatal("wrong allocations observed", stats)
+	}
+}
@@ -0,0 +1,14 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// WithNaNsLast makes a Sorter created with NewFloatSorterWith put NaNs at the end of sorted slices,
+// instead of at the start. This applies in either sort order.
+func WithNaNsLast() zermelo.Option {
+	return func(o *internal.Options) {
+		o.NaNsLast = true
+	}
+}
@@ -0,0 +1,67 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSorterOptions(t *testing.T) {
+	testSorterOptions[float32](t, randFloat32(true), false, false)
+	testSorterOptions[float64](t, randFloat64(true), false, true)
+	testSorterOptions[float32](t, randFloat32(true), true, false)
+	testSorterOptions[float64](t, randFloat64(true), true, true)
+}
+
+func testSorterOptions[F Float](t *testing.T, gen func() F, descending, nansLast bool) {
+	opts := []zermelo.Option{zermelo.WithGrowthFactor(1), zermelo.WithParallelism(2)}
+	if descending {
+		opts = append(opts, zermelo.WithDescending())
+	}
+	if nansLast {
+		opts = append(opts, WithNaNsLast())
+	}
+	test := NewFloatSorterWith[F](opts...)
+	for i := 0; i < testSize; i++ {
+		toTest := make([]F, i)
+		internal.FillSlice(toTest, gen)
+		control := slices.Clone(toTest)
+		sortSort(control) // NaNs first
+		nans := slices.IndexFunc(control, func(f F) bool { return !isNaN(f) })
+		if nans < 0 {
+			nans = len(control)
+		}
+		if descending {
+			slices.Reverse(control[nans:])
+		}
+		if nansLast {
+			control = append(slices.Clone(control[nans:]), control[:nans]...)
+		}
+		test.Sort(toTest)
+		if !floatSlicesEqual(control, toTest) {
+			t.Fatal("descending=", descending, "nansLast=", nansLast, control, toTest)
+		}
+	}
+}
+
+func TestWithSmallSorter(t *testing.T) {
+	calls := 0
+	test := NewFloatSorterWith[float64](zermelo.WithSmallSorter(func(x []float64) {
+		calls++
+		slices.Sort(x)
+	}), zermelo.WithCutoff(20))
+	for n := 0; n < 40; n++ {
+		x := make([]float64, n)
+		internal.FillSlice(x, randFloat64(false))
+		control := slices.Clone(x)
+		slices.Sort(control)
+		test.Sort(x)
+		if !floatSlicesEqual(control, x) {
+			t.Fatal(control, x)
+		}
+	}
+	if calls != 20 {
+		t.Fatal("wrong small sorter calls", calls)
+	}
+}
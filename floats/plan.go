@@ -0,0 +1,28 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2"
+)
+
+// Plan describes how SortFloats would sort a slice of n elements of type F with no NaNs, without sorting anything.
+// It uses the process-wide cutoff for F, see SetCutoff, as SortFloats does.
+func Plan[F Float](n int) zermelo.PlanInfo {
+	if n < 2 || n < Cutoff[F]() {
+		return zermelo.PlanInfo{Size: floatSize[F](), Signed: true, Algorithm: zermelo.Comparison}
+	}
+	return PlanBYOB[F](n)
+}
+
+// PlanBYOB describes how SortFloatsBYOB would sort a slice of n elements of type F with no NaNs,
+// without sorting anything. ScratchBytes is the least buffer SortFloatsBYOB must be given.
+func PlanBYOB[F Float](n int) zermelo.PlanInfo {
+	var result zermelo.PlanInfo
+	if isFloat32[F]() {
+		result = zermelo.PlanBYOB[uint32](n)
+	} else {
+		result = zermelo.PlanBYOB[uint64](n)
+	}
+	result.Signed = true
+	result.DigitBits = 8 // Float bits are sorted a byte at a time, flipped to float order in the first pass
+	return result
+}
@@ -0,0 +1,24 @@
+package floats
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	testPlan(t, Plan[float32](compSortCutoffFloat32-1),
+		zermelo.PlanInfo{Size: 32, Signed: true, Algorithm: zermelo.Comparison})
+	testPlan(t, Plan[float32](compSortCutoffFloat32),
+		zermelo.PlanInfo{Size: 32, Signed: true, Algorithm: zermelo.Radix, DigitBits: 8, Passes: 4,
+			ScratchBytes: 4 * compSortCutoffFloat32})
+	testPlan(t, Plan[float64](compSortCutoffFloat64-1),
+		zermelo.PlanInfo{Size: 64, Signed: true, Algorithm: zermelo.Comparison})
+	testPlan(t, PlanBYOB[float64](2),
+		zermelo.PlanInfo{Size: 64, Signed: true, Algorithm: zermelo.Radix, DigitBits: 8, Passes: 8, ScratchBytes: 16})
+}
+
+func testPlan(t *testing.T, got, expected zermelo.PlanInfo) {
+	if got != expected {
+		t.Fatalf("wrong plan, expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,166 @@
+package zermelo
+
+import (
+	"sync/atomic"
+)
+
+// hybridMin is the fewest bytes of elements to sort with hybridSort. Beyond this, a pass of lsdRadixSort over all of
+// the slice no longer fits in the L2 cache of most CPUs.
+const hybridMin = 1 << 23
+
+// useHybrid returns true if n elements that are size bits wide should be sorted with hybridSort.
+func useHybrid(size uint, n int) bool {
+	return size > radix && n*int(size/8) >= hybridMin
+}
+
+// hybridSort is radixSort for slices too large for the CPU cache. Rather than streaming all of x through memory
+// once per digit, one most significant digit first pass partitions x into buffer by the highest byte not shared by
+// every element, then each bucket is copied back and sorted least significant digit first while it fits in cache,
+// with digits as wide as its length calls for, see digitBits. Buckets shorter than the cutoff of short are sorted by
+// it instead. Up to parallelism goroutines sort buckets at once.
+//
+// If floatBits is not zero, elements are the bits of floats that wide, flipped by the partition and unflipped as
+// each bucket is sorted, see floatBitsSort.
+//
+// Passes are reported as byte passes whatever the width of the digits sorting buckets. The partition is the pass of
+// its byte, and passes over higher bytes, shared by every element, are skipped. The remaining passes are reported as
+// the buckets holding that share of x are sorted, or all at once when sorting buckets in parallel. Sorting is only
+// stopped between passes.
+func hybridSort[T Integer](x, buffer []T, size uint, minval T, floatBits uint, short shortSort[T], parallelism int,
+	pass passFunc) error {
+	var all [maxPasses][256]int
+	passes := int(size / radix)
+	var sorted bool
+	if floatBits != 0 {
+		sorted = flippedHistograms(x, all[:passes], floatBits)
+	} else {
+		sorted = histograms(x, all[:passes], minval)
+	}
+	if sorted { // Short-circuit sorted
+		return nil
+	}
+	top := passes - 1 // Partition by the highest byte that would move elements, as when keys share upper bytes
+	for top > 0 && trivialPass(all[top][:], len(x)) {
+		top--
+	}
+	counts := all[top]
+	shift := uint(top) * radix
+
+	signed := minval != 0 && top == passes-1
+	bucketOffsets(&counts, signed)
+	starts := counts
+	buffer = buffer[:len(x)]
+	if d := newDigits(size, radix, false); useStagedScatter(d, len(x)) {
+		stage := newStager[T](d)
+		stage.scatter(x, buffer, counts[:], d, shift, floatBits)
+		stage.release()
+	} else if floatBits != 0 {
+		for _, elem := range x {
+			elem = T(flipFloat(uint64(elem), floatBits))
+			key := uint8(elem >> shift)
+			buffer[counts[key]] = elem
+			counts[key]++
+		}
+	} else {
+		for _, elem := range x {
+			key := uint8(elem >> shift)
+			buffer[counts[key]] = elem
+			counts[key]++
+		}
+	}
+	restore := func(to, from []T) { // Moves elements left in buffer back to x when sorting is stopped
+		if floatBits != 0 {
+			unflipFloatBits(to, from, floatBits)
+		} else {
+			copy(to, from)
+		}
+	}
+	done := passes - top
+	if pass != nil {
+		if err := pass(done); err != nil {
+			restore(x, buffer)
+			return err
+		}
+	}
+
+	// Buckets in the order they were laid out, negatives first
+	var flip int
+	if signed {
+		flip = 128
+	}
+	bucket := func(i int) (lo, hi int) {
+		key := i ^ flip
+		return starts[key], counts[key]
+	}
+	// An error for the last pass of float bits comes too late, as they are sorted by then, see floatBitsSort
+	stopped := func(err error) bool {
+		return err != nil && (floatBits == 0 || done < passes)
+	}
+	if parallelism > 1 {
+		var next atomic.Int64
+		parallelDo(parallelism, func(int) {
+			for i := int(next.Add(1) - 1); i < 256; i = int(next.Add(1) - 1) {
+				lo, hi := bucket(i)
+				sortBucket(x[lo:hi], buffer[lo:hi], size, minval, floatBits, short)
+			}
+		})
+		for pass != nil && done < passes {
+			done++
+			if err := pass(done); stopped(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := 0; i < 256; i++ {
+		lo, hi := bucket(i)
+		sortBucket(x[lo:hi], buffer[lo:hi], size, minval, floatBits, short)
+		for target := passes - top + top*hi/len(x); pass != nil && done < target; {
+			done++
+			if err := pass(done); stopped(err) {
+				restore(x[hi:], buffer[hi:])
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sortBucket sorts the elements of bucket into x, using bucket as the radix sort buffer.
+// Float bits are unflipped as they are sorted, see hybridSort.
+func sortBucket[T Integer](x, bucket []T, size uint, minval T, floatBits uint, short shortSort[T]) {
+	copy(x, bucket)
+	switch {
+	case short.sortShort(x, size):
+		if floatBits != 0 {
+			unflipFloatBits(x, x, floatBits)
+		}
+	case floatBits != 0:
+		_ = floatRadixSort(x, bucket, floatBits, true, nil)
+	default:
+		_ = lsdRadixSort(x, bucket, size, minval, nil)
+	}
+}
+
+// shortSort is how slices too short for radix sort are sorted, as a Sorter does with its cutoff and SmallSorter.
+// The zero shortSort uses the process-wide cutoff and SmallSort, as Sort does.
+type shortSort[T Integer] struct {
+	cutoff int // only used if sort is not nil
+	sort   SmallSorter[T]
+}
+
+// sortShort sorts x and returns true if it is shorter than the cutoff for elements that are size bits wide.
+func (s shortSort[T]) sortShort(x []T, size uint) bool {
+	if s.sort == nil {
+		if len(x) >= cutoffs.Get(size) {
+			return false
+		}
+		SmallSort(x)
+		return true
+	}
+	if len(x) >= s.cutoff {
+		return false
+	}
+	s.sort(x)
+	return true
+}
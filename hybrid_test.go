@@ -0,0 +1,126 @@
+package zermelo
+
+import (
+	"errors"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestHybridSort(t *testing.T) {
+	for _, parallelism := range []int{1, 3} {
+		testHybridSort[int16](t, internal.RandInteger[int16](), parallelism)
+		testHybridSort[int32](t, internal.RandInteger[int32](), parallelism)
+		testHybridSort[uint32](t, internal.RandInteger[uint32](), parallelism)
+		testHybridSort[int64](t, internal.RandInteger[int64](), parallelism)
+		testHybridSort[uint64](t, internal.RandInteger[uint64](), parallelism)
+		// Shared top bytes, partitioned by a lower byte
+		testHybridSort[uint64](t, func() uint64 { return uint64(internal.RandInteger[uint32]()()) }, parallelism)
+		testHybridSort[int32](t, func() int32 { return -1 - int32(internal.RandInteger[uint16]()()) }, parallelism)
+		// Few buckets, mostly too small for radix sort
+		testHybridSort[int64](t, func() int64 { return int64(internal.RandInteger[int16]()()) << 48 }, parallelism)
+	}
+}
+
+func testHybridSort[T Integer](t *testing.T, gen func() T, parallelism int) {
+	size, minval := internal.Detect[T]()
+	passes := int(size / radix)
+	for _, n := range []int{2, testSize, 100000} {
+		toTest := make([]T, n)
+		internal.FillSlice(toTest, gen)
+		control := slices.Clone(toTest)
+		slices.Sort(control)
+		var done []int
+		pass := func(d int) error {
+			done = append(done, d)
+			return nil
+		}
+		if err := hybridSort(toTest, make([]T, n), size, minval, 0, shortSort[T]{}, parallelism, pass); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(control, toTest) {
+			t.Fatalf("%T: hybrid sort of %d failed", T(0), n)
+		}
+		if len(done) > 0 && (done[len(done)-1] > passes || !slices.IsSorted(done)) {
+			t.Fatalf("%T: wrong passes for %d: %v", T(0), n, done)
+		}
+		done = nil
+		err := hybridSort(toTest, make([]T, n), size, minval, 0, shortSort[T]{}, parallelism, pass)
+		if err != nil || len(done) != 0 {
+			t.Fatalf("%T: sorted hybrid sort of %d not short-circuited: %v", T(0), n, done)
+		}
+	}
+}
+
+func TestHybridSortPasses(t *testing.T) {
+	x := make([]uint32, 100000)
+	internal.FillSlice(x, internal.RandInteger[uint32]())
+	var done []int
+	err := hybridSort(x, make([]uint32, len(x)), 32, 0, 0, shortSort[uint32]{}, 1, func(d int) error {
+		done = append(done, d)
+		return nil
+	})
+	if err != nil || !slices.Equal(done, []int{1, 2, 3, 4}) || !slices.IsSorted(x) {
+		t.Fatal("wrong passes", err, done)
+	}
+
+	// The shared top byte is skipped, and the partition is the pass of the byte below it
+	internal.FillSlice(x, func() uint32 { return internal.RandInteger[uint32]()() >> 8 })
+	done = nil
+	err = hybridSort(x, make([]uint32, len(x)), 32, 0, 0, shortSort[uint32]{}, 1, func(d int) error {
+		done = append(done, d)
+		return nil
+	})
+	if err != nil || !slices.Equal(done, []int{2, 3, 4}) || !slices.IsSorted(x) {
+		t.Fatal("wrong passes with shared top byte", err, done)
+	}
+}
+
+func TestSortSharedTopBytes(t *testing.T) {
+	var (
+		stats    Stats
+		progress [][2]int
+	)
+	test := NewSorterWith[uint32](WithObserver(func(s Stats) { stats = s }), WithProgress(func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	}))
+	x := make([]uint32, hybridMin/4)
+	internal.FillSlice(x, func() uint32 { return internal.RandInteger[uint32]()() >> 8 })
+	test.Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("sort with shared top byte failed")
+	}
+	plan := Plan[uint32](len(x))
+	if !plan.Partitioned || stats.Passes+stats.SkippedPasses != plan.Passes || stats.SkippedPasses != 1 {
+		t.Fatal("wrong passes", plan, stats)
+	}
+	if !slices.Equal(progress, [][2]int{{2, 4}, {3, 4}, {4, 4}}) {
+		t.Fatal("wrong progress", progress)
+	}
+}
+
+func TestHybridSortCancel(t *testing.T) {
+	stop := errors.New("stop")
+	_, minval := internal.Detect[int64]()
+	for _, at := range []int{1, 2, 4} {
+		for _, parallelism := range []int{1, 3} {
+			x := make([]int64, 100000)
+			internal.FillSlice(x, internal.RandInteger[int64]())
+			control := slices.Clone(x)
+			slices.Sort(control)
+			err := hybridSort(x, make([]int64, len(x)), 64, minval, 0, shortSort[int64]{}, parallelism, func(d int) error {
+				if d == at {
+					return stop
+				}
+				return nil
+			})
+			if !errors.Is(err, stop) {
+				t.Fatal("sort not stopped at pass", at, err)
+			}
+			slices.Sort(x)
+			if !slices.Equal(control, x) {
+				t.Fatal("elements lost when stopped at pass", at)
+			}
+		}
+	}
+}
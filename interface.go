@@ -0,0 +1,99 @@
+package zermelo
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// KeyInterface is a sort.Interface whose elements also have unsigned integer keys, see SortInterface.
+type KeyInterface interface {
+	sort.Interface
+	// Key returns the key of the element with index i.
+	Key(i int) uint64
+}
+
+// interfaceCutoff is the shortest bucket SortInterface radix sorts rather than insertion sorts.
+const interfaceCutoff = 32
+
+// SortInterface sorts data in place by the keys of its elements, for containers that cannot be sorted as slices.
+// It is an American flag sort, a most significant byte first radix sort that moves elements only with Swap,
+// needing no buffer. Elements are ordered by Key alone and Less is never called, so the order is only that of
+// sort.Sort if Less agrees with Key. The sort is not stable.
+func SortInterface(data KeyInterface) {
+	n := data.Len()
+	if n < 2 {
+		return
+	}
+	// Bytes above the highest bit where any key differs from the first are shared, and skipped
+	first, diff := data.Key(0), uint64(0)
+	for i := 1; i < n; i++ {
+		diff |= data.Key(i) ^ first
+	}
+	if diff == 0 {
+		return
+	}
+	top := uint(bits.Len64(diff)-1) / radix * radix
+	americanFlag(data, 0, n, top)
+}
+
+// americanFlag sorts the elements of data from lo up to hi, whose keys are all equal above the byte at shift,
+// by that byte and then recursively by the bytes below it.
+func americanFlag(data KeyInterface, lo, hi int, shift uint) {
+	var counts [256]int
+	for ; ; shift -= radix { // Skip bytes that all keys share
+		if hi-lo < interfaceCutoff {
+			insertionSortKeys(data, lo, hi)
+			return
+		}
+		clear(counts[:])
+		for i := lo; i < hi; i++ {
+			counts[uint8(data.Key(i)>>shift)]++
+		}
+		if !trivialPass(counts[:], hi-lo) {
+			break
+		}
+		if shift == 0 { // All keys are equal
+			return
+		}
+	}
+
+	// heads[b] is the next element of bucket b to place, tails[b] the end of bucket b
+	var heads, tails [256]int
+	watermark := lo
+	for b, count := range counts {
+		heads[b] = watermark
+		watermark += count
+		tails[b] = watermark
+	}
+	for b := range heads {
+		for heads[b] < tails[b] {
+			// Swap the element at the head of b into its own bucket, until one belonging to b is found
+			if key := uint8(data.Key(heads[b]) >> shift); int(key) == b {
+				heads[b]++
+			} else {
+				data.Swap(heads[b], heads[key])
+				heads[key]++
+			}
+		}
+	}
+
+	if shift == 0 {
+		return
+	}
+	start := lo
+	for _, end := range tails {
+		if end-start > 1 {
+			americanFlag(data, start, end, shift-radix)
+		}
+		start = end
+	}
+}
+
+// insertionSortKeys sorts the elements of data from lo up to hi by key with insertion sort.
+func insertionSortKeys(data KeyInterface, lo, hi int) {
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && data.Key(j) < data.Key(j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
+}
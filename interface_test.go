@@ -0,0 +1,57 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+// testColumns is a KeyInterface over parallel slices, as for a struct of arrays.
+type testColumns struct {
+	keys  []uint64
+	index []int
+}
+
+func (c *testColumns) Len() int           { return len(c.keys) }
+func (c *testColumns) Less(i, j int) bool { panic("Less called") }
+func (c *testColumns) Key(i int) uint64   { return c.keys[i] }
+func (c *testColumns) Swap(i, j int) {
+	c.keys[i], c.keys[j] = c.keys[j], c.keys[i]
+	c.index[i], c.index[j] = c.index[j], c.index[i]
+}
+
+func TestSortInterface(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n    int
+		mask uint64
+	}{
+		{"empty", 0, ^uint64(0)},
+		{"short", interfaceCutoff - 1, ^uint64(0)},
+		{"random", testSize, ^uint64(0)},
+		{"large", 1 << 16, ^uint64(0)},
+		{"low bytes", testSize, 0xffff},
+		{"gaps", 1 << 14, 0xff0000ff000000ff},
+		{"few", testSize, 3 << 40},
+		{"equal", testSize, 0},
+	} {
+		c := &testColumns{keys: make([]uint64, tc.n), index: make([]int, tc.n)}
+		internal.FillSlice(c.keys, internal.RandInteger[uint64]())
+		for i := range c.keys {
+			c.keys[i] = c.keys[i]&tc.mask | 0x0100000000000000
+			c.index[i] = i
+		}
+		original := slices.Clone(c.keys)
+		control := slices.Clone(c.keys)
+		slices.Sort(control)
+		SortInterface(c)
+		if !slices.Equal(control, c.keys) {
+			t.Fatalf("%s: keys not sorted", tc.name)
+		}
+		for i, index := range c.index {
+			if original[index] != c.keys[i] {
+				t.Fatalf("%s: elements not swapped together at %d", tc.name, i)
+			}
+		}
+	}
+}
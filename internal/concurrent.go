@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// Sorter is the zermelo Sorter interface, without its type constraint.
+type Sorter[T any] interface {
+	Sort(x []T)
+}
+
+// ConcurrentSorter sorts with sorters borrowed from a pool, so it is safe for concurrent use.
+// sync.Pool keeps sorters per P, so goroutines rarely contend, and unused sorters are freed by the garbage collector.
+type ConcurrentSorter[T any] struct {
+	sorters sync.Pool
+}
+
+// NewConcurrentSorter returns a ConcurrentSorter that creates sorters with newSorter as needed.
+func NewConcurrentSorter[T any](newSorter func() Sorter[T]) *ConcurrentSorter[T] {
+	result := &ConcurrentSorter[T]{}
+	result.sorters.New = func() any { return newSorter() }
+	return result
+}
+
+// Sort sorts x with a pooled sorter.
+func (c *ConcurrentSorter[T]) Sort(x []T) {
+	s := c.sorters.Get().(Sorter[T])
+	s.Sort(x)
+	c.sorters.Put(s)
+}
+
+// SortContext sorts x with a pooled sorter, stopping early if ctx is done.
+// If the pooled sorters do not support cancellation, ctx is only checked before sorting.
+func (c *ConcurrentSorter[T]) SortContext(ctx context.Context, x []T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := c.sorters.Get().(Sorter[T])
+	defer c.sorters.Put(s)
+	if cs, ok := s.(interface {
+		SortContext(context.Context, []T) error
+	}); ok {
+		return cs.SortContext(ctx, x)
+	}
+	s.Sort(x)
+	return nil
+}
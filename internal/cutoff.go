@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultCutoff is the Options.Cutoff value meaning the process-wide cutoff for the element size is used.
+	DefaultCutoff = -1
+
+	calibrateMin    = 16      // smallest slice length tried by Crossover
+	calibrateMax    = 4096    // largest slice length tried by Crossover, returned if radix sort never wins
+	calibrateElems  = 1 << 13 // elements sorted per timing in Crossover
+	calibrateRounds = 3       // timings per slice length in Crossover, the fastest is kept
+)
+
+// Cutoffs holds comparison sort cutoffs for 8, 16, 32 and 64 bit elements. It is safe for concurrent use.
+type Cutoffs struct {
+	n [4]atomic.Int64
+}
+
+// NewCutoffs returns Cutoffs initialized with the given cutoff for each element size.
+func NewCutoffs(c8, c16, c32, c64 int) *Cutoffs {
+	result := &Cutoffs{}
+	for i, c := range []int{c8, c16, c32, c64} {
+		result.n[i].Store(int64(c))
+	}
+	return result
+}
+
+// Get returns the cutoff for elements of the given size in bits.
+func (c *Cutoffs) Get(size uint) int {
+	return int(c.n[cutoffIndex(size)].Load())
+}
+
+// Set sets the cutoff for elements of the given size in bits.
+func (c *Cutoffs) Set(size uint, cutoff int) {
+	c.n[cutoffIndex(size)].Store(int64(max(cutoff, 0)))
+}
+
+// cutoffIndex maps 8, 16, 32 and 64 to 0, 1, 2 and 3
+func cutoffIndex(size uint) int {
+	return bits.Len(size) - 4
+}
+
+// Crossover estimates the smallest slice length at which radixSort beats slices.Sort on this machine,
+// using random data from gen. radixSort must sort x using buf, which is at least as long as x.
+func Crossover[T cmp.Ordered](gen func() T, radixSort func(x, buf []T)) int {
+	input := make([]T, calibrateMax)
+	FillSlice(input, gen)
+	work := make([]T, calibrateElems)
+	buf := make([]T, calibrateMax)
+
+	streak := 0 // radix sort must win at two lengths in a row, to filter out noise
+	for n, prev := calibrateMin, 0; n <= calibrateMax; prev, n = n, n+n/4 {
+		comp := timeSort(input[:n], work, slices.Sort[[]T])
+		radix := timeSort(input[:n], work, func(x []T) { radixSort(x, buf) })
+		if radix >= comp {
+			streak = 0
+			continue
+		}
+		if streak++; streak == 2 {
+			return prev
+		}
+	}
+	return calibrateMax
+}
+
+// timeSort returns the fastest of several timings of sortFunc sorting copies of input stored in work.
+func timeSort[T any](input, work []T, sortFunc func([]T)) time.Duration {
+	copies := max(1, len(work)/len(input))
+	best := time.Duration(1<<63 - 1)
+	for round := 0; round < calibrateRounds; round++ {
+		for i := 0; i < copies; i++ {
+			copy(work[i*len(input):], input)
+		}
+		start := time.Now()
+		for i := 0; i < copies; i++ {
+			sortFunc(work[i*len(input) : (i+1)*len(input)])
+		}
+		best = min(best, time.Since(start))
+	}
+	return best
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestCutoffs(t *testing.T) {
+	c := NewCutoffs(1, 2, 3, 4)
+	for i, size := range []uint{8, 16, 32, 64} {
+		if c.Get(size) != i+1 {
+			t.Fatalf("wrong cutoff for size %d: %d", size, c.Get(size))
+		}
+	}
+	c.Set(32, 100)
+	if c.Get(32) != 100 || c.Get(64) != 4 {
+		t.Fatal("wrong cutoffs after set", c.Get(32), c.Get(64))
+	}
+	c.Set(8, -1)
+	if c.Get(8) != 0 {
+		t.Fatal("negative cutoff not clamped", c.Get(8))
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	// a "radix sort" that does nothing always wins
+	noop := func(x, buf []uint32) {}
+	if n := Crossover(RandInteger[uint32](), noop); n != calibrateMin {
+		t.Fatal("noop sort crossed over at", n)
+	}
+}
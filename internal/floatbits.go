@@ -0,0 +1,10 @@
+package internal
+
+// SortFloatBits32 and SortFloatBits64 radix sort the bits of float32 and float64 slices, as unsigned integers,
+// in float order with the given buffer. The slices must not hold NaNs. allocBytes is the size of the buffer if it
+// was allocated for this sort, or zero, to be reported to observers. They are set by the zermelo package,
+// so the floats package can use its radix sorts.
+var (
+	SortFloatBits32 func(x, buffer []uint32, allocBytes int)
+	SortFloatBits64 func(x, buffer []uint64, allocBytes int)
+)
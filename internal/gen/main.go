@@ -0,0 +1,220 @@
+// Command gen writes specialized.go, copies of the radix sort hot loops for each built-in integer type,
+// and networks.go, unrolled sorting networks for short slices.
+//
+// Generic code is compiled once per GC shape, so the loops are specialized by hand rather than trusting
+// the compiler to do so. Run it with go generate from the root of the module.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+)
+
+// typ is a built-in integer type to specialize for.
+type typ struct {
+	Name   string // Go type name
+	Func   string // suffix of specialized function names
+	Min    string // smallest value, as Go source
+	Passes []int  // possible radix sort passes, more than one if the size is platform dependent
+	Kernel string // size of the assembly kernels for this type, if any
+}
+
+var types = []typ{
+	{Name: "int", Func: "Int", Min: "math.MinInt", Passes: []int{4, 8}},
+	{Name: "int64", Func: "Int64", Min: "math.MinInt64", Passes: []int{8}},
+	{Name: "int32", Func: "Int32", Min: "math.MinInt32", Passes: []int{4}},
+	{Name: "uint64", Func: "Uint64", Min: "0", Passes: []int{8}, Kernel: "64"},
+	{Name: "uint32", Func: "Uint32", Min: "0", Passes: []int{4}, Kernel: "32"},
+}
+
+var funcs = template.FuncMap{
+	"upto": func(n int) []int {
+		var result []int
+		for i := 0; i < n; i++ {
+			result = append(result, i)
+		}
+		return result
+	},
+	"mul":  func(a, b int) int { return a * b },
+	"last": func(x []int) int { return len(x) - 1 },
+}
+
+var source = template.Must(template.New("specialized").Funcs(funcs).Parse(`// Code generated by go run ./internal/gen. DO NOT EDIT.
+
+package zermelo
+
+import (
+	"math"
+)
+
+// specializedHistograms is histograms without generic code, for the built-in integer types.
+// It returns ok false if there is no specialization for T.
+func specializedHistograms[T Integer](x []T, counts [][256]int) (sorted, ok bool) {
+	switch x := any(x).(type) {
+{{- range .}}
+	case []{{.Name}}:
+{{- if .Kernel}}
+		if haveKernels {
+			return kernelHistograms{{.Kernel}}(x, counts), true
+		}
+{{- end}}
+		return histograms{{.Func}}(x, counts), true
+{{- end}}
+	}
+	return false, false
+}
+
+// specializedScatter is the scatter loop of lsdRadixSort without generic code, for the built-in integer types.
+// It returns false if there is no specialization for T.
+func specializedScatter[T Integer](from, to []T, offset *[256]int, shift uint) bool {
+	switch from := any(from).(type) {
+{{- range .}}
+	case []{{.Name}}:
+{{- if .Kernel}}
+		if haveKernels {
+			scatter{{.Kernel}}(from, any(to).([]{{.Name}})[:len(from)], offset, shift)
+			return true
+		}
+{{- end}}
+		scatter{{.Func}}(from, any(to).([]{{.Name}}), offset, shift)
+		return true
+{{- end}}
+	}
+	return false
+}
+{{range $t := .}}
+// histograms{{.Func}} is histograms for []{{.Name}}.
+func histograms{{.Func}}(x []{{.Name}}, counts [][256]int) bool {
+	var (
+		prev   = {{.Name}}({{.Min}})
+		sorted = true
+	)
+{{- if eq (len .Passes) 1}}
+{{- template "count" index .Passes 0}}
+{{- else}}
+	switch len(counts) {
+{{- range $i, $passes := .Passes}}
+{{- if lt $i (last $t.Passes)}}
+	case {{$passes}}:
+{{- else}}
+	default:
+{{- end}}
+{{- template "count" $passes}}
+{{- end}}
+	}
+{{- end}}
+	return sorted
+}
+
+// scatter{{.Func}} is the scatter loop of lsdRadixSort for []{{.Name}}.
+func scatter{{.Func}}(from, to []{{.Name}}, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
+{{end}}
+{{- define "count"}}
+{{- range upto .}}
+	c{{.}} := &counts[{{.}}]
+{{- end}}
+	for _, elem := range x {
+		u := uint64(elem)
+{{- range upto .}}
+		c{{.}}[uint8(u{{if .}}>>{{mul . 8}}{{end}})]++
+{{- end}}
+		if sorted {
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+{{- end}}`))
+
+// maxNetwork is the longest slice sorted by a generated sorting network.
+const maxNetwork = 16
+
+// network is a sorting network for slices of length N, as the pairs of indexes compared and exchanged in order.
+type network struct {
+	N     int
+	Pairs [][2]int
+}
+
+// batcher returns Batcher's odd-even merge sort network for n elements. It is built for the next power of two,
+// dropping comparators past n, which is as if the missing elements were larger than any other.
+func batcher(n int) network {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	result := network{N: n}
+	for p := 1; p < size; p *= 2 {
+		for k := p; k >= 1; k /= 2 {
+			for j := k % p; j+k < size; j += 2 * k {
+				for i := 0; i < k && i+j+k < size; i++ {
+					a, b := i+j, i+j+k
+					if a/(2*p) == b/(2*p) && b < n {
+						result.Pairs = append(result.Pairs, [2]int{a, b})
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+var networkSource = template.Must(template.New("networks").Funcs(template.FuncMap{
+	"maxNetwork": func() int { return maxNetwork },
+}).Parse(`// Code generated by go run ./internal/gen. DO NOT EDIT.
+
+package zermelo
+
+// sortNetwork sorts x with a sorting network if it has at most {{maxNetwork}} elements, returning false if it is longer.
+func sortNetwork[T Integer](x []T) bool {
+	switch len(x) {
+	case 0, 1:
+{{- range .}}
+	case {{.N}}:
+		network{{.N}}(x)
+{{- end}}
+	default:
+		return false
+	}
+	return true
+}
+{{range .}}
+// network{{.N}} sorts {{.N}} elements with {{len .Pairs}} branchless compare-exchanges.
+func network{{.N}}[T Integer](x []T) {
+	_ = x[{{.N}}-1]
+{{- range .Pairs}}
+	x[{{index . 0}}], x[{{index . 1}}] = min(x[{{index . 0}}], x[{{index . 1}}]), max(x[{{index . 0}}], x[{{index . 1}}])
+{{- end}}
+}
+{{end}}`))
+
+// write executes t with data and writes the formatted result to the named file.
+func write(name string, t *template.Template, data any) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err, "\n", buf.String())
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	write("specialized.go", source, types)
+	var networks []network
+	for n := 2; n <= maxNetwork; n++ {
+		networks = append(networks, batcher(n))
+	}
+	write("networks.go", networkSource, networks)
+}
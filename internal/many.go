@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// SortMany sorts each slice in xs using up to workers goroutines, or GOMAXPROCS if workers is not positive.
+// Each goroutine sorts with its own sorter from newSorter, taking the next unsorted slice until none are left.
+func SortMany[T any](xs [][]T, workers int, newSorter func() Sorter[T]) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(xs))
+	if workers <= 1 {
+		if len(xs) > 0 {
+			s := newSorter()
+			for _, x := range xs {
+				s.Sort(x)
+			}
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			s := newSorter()
+			for i := next.Add(1) - 1; i < int64(len(xs)); i = next.Add(1) - 1 {
+				s.Sort(xs[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
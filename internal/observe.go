@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"sync/atomic"
+)
+
+// observer holds the process-wide observer, a zermelo.Observer. It is stored as any because that type belongs to
+// the zermelo package, which the floats package reads it through too.
+var observer atomic.Pointer[any]
+
+// SetObserver sets the process-wide observer.
+func SetObserver(o any) {
+	observer.Store(&o)
+}
+
+// LoadObserver returns the process-wide observer, or nil if none was set.
+func LoadObserver() any {
+	if o := observer.Load(); o != nil {
+		return *o
+	}
+	return nil
+}
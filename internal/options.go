@@ -0,0 +1,41 @@
+package internal
+
+// Options holds the settings configurable on a Sorter.
+type Options struct {
+	// Cutoff is the slice length below which comparison sort is used, or DefaultCutoff.
+	Cutoff int
+	// Growth is the factor by which the buffer is over-allocated when it grows.
+	Growth float64
+	// Capacity is the length of the buffer allocated when the Sorter is created.
+	Capacity int
+	// MaxRetained is the longest buffer kept between sorts, or zero for no limit.
+	MaxRetained int
+	// Descending is true if slices are sorted in descending order.
+	Descending bool
+	// Parallelism is the most goroutines used to sort one large slice.
+	Parallelism int
+	// Progress, if not nil, is called as sorting progresses with the steps done and the total steps.
+	Progress func(done, total int)
+	// Observer, if not nil, is a zermelo.Observer called after each sort.
+	Observer any
+	// SmallSorter, if not nil, is a zermelo.SmallSorter used below the cutoff, for sorters of its element type.
+	SmallSorter any
+	// NaNsLast is true if float sorters put NaNs at the end instead of the start. Unused for integers.
+	NaNsLast bool
+	// FloatBits is true if a Sorter of unsigned integers sorts the bits of floats, in float order.
+	// Float sorters set this for the Sorter they wrap.
+	FloatBits bool
+}
+
+// ApplyOptions returns default Options modified by each of opts in order.
+func ApplyOptions[O ~func(*Options)](opts []O) Options {
+	result := Options{
+		Cutoff:      DefaultCutoff,
+		Growth:      1.25,
+		Parallelism: 1,
+	}
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultMaxPooled is the default longest buffer, in elements, kept in buffer pools.
+	DefaultMaxPooled = 1 << 20
+	// minPoolClass is the smallest size class, buffers are at least 1<<minPoolClass elements.
+	minPoolClass = 6
+)
+
+var (
+	poolingDisabled atomic.Bool
+	maxPooled       atomic.Int64
+	// pools maps a typed nil pointer *T to the size classed pools for []T.
+	pools sync.Map
+)
+
+func init() {
+	maxPooled.Store(DefaultMaxPooled)
+}
+
+// bufferPools holds one pool per power of two size class. Pools hold *[]T with len(*b) == 1<<class.
+type bufferPools [bits.UintSize]sync.Pool
+
+// SetPooling enables or disables buffer pools. If disabled, GetBuffer always allocates.
+func SetPooling(enabled bool) {
+	poolingDisabled.Store(!enabled)
+}
+
+// SetMaxPooled sets the longest buffer, in elements, kept in buffer pools.
+func SetMaxPooled(n int) {
+	maxPooled.Store(int64(max(n, 0)))
+}
+
+// GetBuffer returns a buffer of at least n elements, from a pool if possible, and true if it had to be allocated.
+// The buffer should be returned with PutBuffer when no longer in use.
+func GetBuffer[T any](n int) (*[]T, bool) {
+	class := poolClass(n)
+	if !pooled(1 << class) {
+		buf := make([]T, n)
+		return &buf, true
+	}
+	if buf, ok := poolsFor[T]()[class].Get().(*[]T); ok {
+		return buf, false
+	}
+	buf := make([]T, 1<<class)
+	return &buf, true
+}
+
+// PutBuffer returns a buffer from GetBuffer to its pool, unless it is too large or not a pooled size.
+func PutBuffer[T any](buf *[]T) {
+	n := len(*buf)
+	if !pooled(n) || n < 1<<minPoolClass || n&(n-1) != 0 {
+		return
+	}
+	poolsFor[T]()[bits.Len(uint(n))-1].Put(buf)
+}
+
+// pooled returns true if buffers of n elements may be pooled
+func pooled(n int) bool {
+	return !poolingDisabled.Load() && int64(n) <= maxPooled.Load()
+}
+
+// poolClass returns the size class of buffers able to hold n elements
+func poolClass(n int) int {
+	if n <= 1<<minPoolClass {
+		return minPoolClass
+	}
+	return bits.Len(uint(n - 1))
+}
+
+// poolsFor returns the pools for []T, creating them if needed
+func poolsFor[T any]() *bufferPools {
+	key := (*T)(nil)
+	if p, ok := pools.Load(key); ok {
+		return p.(*bufferPools)
+	}
+	p, _ := pools.LoadOrStore(key, new(bufferPools))
+	return p.(*bufferPools)
+}
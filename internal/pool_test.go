@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestGetBuffer(t *testing.T) {
+	for _, n := range []int{0, 1, 64, 65, 1000, 1 << 16} {
+		buf, _ := GetBuffer[uint32](n)
+		if len(*buf) < n || len(*buf) < 1<<minPoolClass || len(*buf)&(len(*buf)-1) != 0 {
+			t.Fatalf("wrong buffer length %d for %d", len(*buf), n)
+		}
+		PutBuffer(buf)
+	}
+}
+
+func TestGetBufferUnpooled(t *testing.T) {
+	defer SetMaxPooled(DefaultMaxPooled)
+	SetMaxPooled(100)
+	if buf, allocated := GetBuffer[uint64](1000); len(*buf) != 1000 || !allocated {
+		t.Fatal("buffer over max pooled not exact", len(*buf))
+	}
+	if buf, _ := GetBuffer[uint64](100); len(*buf) != 100 {
+		t.Fatal("buffer with size class over max pooled not exact", len(*buf))
+	}
+
+	defer SetPooling(true)
+	SetPooling(false)
+	if buf, _ := GetBuffer[uint64](10); len(*buf) != 10 {
+		t.Fatal("buffer with pooling disabled not exact", len(*buf))
+	}
+}
+
+func TestPutBuffer(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ := GetBuffer[int16](1000)
+		PutBuffer(buf)
+	})
+	if allocs != 0 {
+		t.Fatal("pooled buffers allocated", allocs)
+	}
+	buf, _ := GetBuffer[int8](100)
+	(*buf)[0] = 1
+	PutBuffer(buf)
+	if other, _ := GetBuffer[uint8](100); (*other)[0] != 0 {
+		t.Fatal("buffer shared between types")
+	}
+}
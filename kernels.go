@@ -0,0 +1,43 @@
+package zermelo
+
+// kernelChunk is the most elements counted by one call to a counting kernel, so its uint32 counts cannot overflow.
+const kernelChunk = 1 << 30
+
+// kernelHistograms64 is histograms for []uint64 using the countBytes64 kernel.
+//
+// Kernels count even and odd elements into separate tables, so runs of equal keys do not wait on their own increments.
+func kernelHistograms64(x []uint64, counts [][256]int) bool {
+	var c [2][8][256]uint32
+	sorted := true
+	for start := 0; start < len(x); start += kernelChunk {
+		chunk := x[start:min(start+kernelChunk, len(x))]
+		sorted = countBytes64(chunk, &c) && sorted && (start == 0 || chunk[0] >= x[start-1])
+		addCounts(counts, c[0][:], c[1][:])
+		c = [2][8][256]uint32{}
+	}
+	return sorted
+}
+
+// kernelHistograms32 is kernelHistograms64 for []uint32.
+func kernelHistograms32(x []uint32, counts [][256]int) bool {
+	var c [2][4][256]uint32
+	sorted := true
+	for start := 0; start < len(x); start += kernelChunk {
+		chunk := x[start:min(start+kernelChunk, len(x))]
+		sorted = countBytes32(chunk, &c) && sorted && (start == 0 || chunk[0] >= x[start-1])
+		addCounts(counts, c[0][:], c[1][:])
+		c = [2][4][256]uint32{}
+	}
+	return sorted
+}
+
+// addCounts adds each of the kernel count tables to counts.
+func addCounts(counts [][256]int, tables ...[][256]uint32) {
+	for _, table := range tables {
+		for p := range counts {
+			for key, count := range table[p] {
+				counts[p][key] += int(count)
+			}
+		}
+	}
+}
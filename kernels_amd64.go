@@ -0,0 +1,29 @@
+//go:build amd64 && !purego
+
+package zermelo
+
+// haveKernels is true as there are assembly kernels for amd64. They use only baseline amd64 instructions,
+// so there are no CPU features to detect.
+const haveKernels = true
+
+// countBytes64 counts every byte of each element of x, even elements into counts[0] and odd into counts[1].
+// It returns true if x is sorted.
+//
+//go:noescape
+func countBytes64(x []uint64, counts *[2][8][256]uint32) (sorted bool)
+
+// countBytes32 is countBytes64 for []uint32.
+//
+//go:noescape
+func countBytes32(x []uint32, counts *[2][4][256]uint32) (sorted bool)
+
+// scatter64 copies each element of from to to[offset[key]], where key is its byte shifted right by shift bits,
+// incrementing offset[key]. Offsets must be in range for to, as they are when found from the counts of from.
+//
+//go:noescape
+func scatter64(from, to []uint64, offset *[256]int, shift uint)
+
+// scatter32 is scatter64 for []uint32.
+//
+//go:noescape
+func scatter32(from, to []uint32, offset *[256]int, shift uint)
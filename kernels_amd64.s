@@ -0,0 +1,149 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// COUNT8 counts the 8 bytes of R, least significant first, into the tables at T, destroying R.
+#define COUNT8(R, T) \
+	MOVBQZX R, DX; INCL 0(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 1024(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 2048(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 3072(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 4096(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 5120(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 6144(T)(DX*4); SHRQ $8, R \
+	MOVBQZX R, DX; INCL 7168(T)(DX*4)
+
+// COUNT4 counts the 4 bytes of R, least significant first, into the tables at T, destroying R.
+#define COUNT4(R, T) \
+	MOVBQZX R, DX; INCL 0(T)(DX*4); SHRL $8, R \
+	MOVBQZX R, DX; INCL 1024(T)(DX*4); SHRL $8, R \
+	MOVBQZX R, DX; INCL 2048(T)(DX*4); SHRL $8, R \
+	MOVBQZX R, DX; INCL 3072(T)(DX*4)
+
+// func countBytes64(x []uint64, counts *[2][8][256]uint32) (sorted bool)
+TEXT ·countBytes64(SB), NOSPLIT, $0-33
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	MOVQ counts+24(FP), DI
+	LEAQ 8192(DI), R11 // odd elements table
+	XORQ R8, R8        // previous element
+	XORQ R10, R10      // descents seen
+
+pairs64:
+	CMPQ CX, $2
+	JB   tail64
+	MOVQ 0(SI), AX
+	MOVQ 8(SI), BX
+	CMPQ AX, R8
+	ADCQ $0, R10       // carry if AX < previous
+	CMPQ BX, AX
+	ADCQ $0, R10
+	MOVQ BX, R8
+	COUNT8(AX, DI)
+	COUNT8(BX, R11)
+	ADDQ $16, SI
+	SUBQ $2, CX
+	JMP  pairs64
+
+tail64:
+	TESTQ CX, CX
+	JZ    done64
+	MOVQ  0(SI), AX
+	CMPQ  AX, R8
+	ADCQ  $0, R10
+	COUNT8(AX, DI)
+
+done64:
+	TESTQ R10, R10
+	SETEQ sorted+32(FP)
+	RET
+
+// func countBytes32(x []uint32, counts *[2][4][256]uint32) (sorted bool)
+TEXT ·countBytes32(SB), NOSPLIT, $0-33
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	MOVQ counts+24(FP), DI
+	LEAQ 4096(DI), R11 // odd elements table
+	XORL R8, R8        // previous element
+	XORQ R10, R10      // descents seen
+
+pairs32:
+	CMPQ CX, $2
+	JB   tail32
+	MOVL 0(SI), AX
+	MOVL 4(SI), BX
+	CMPL AX, R8
+	ADCQ $0, R10       // carry if AX < previous
+	CMPL BX, AX
+	ADCQ $0, R10
+	MOVL BX, R8
+	COUNT4(AX, DI)
+	COUNT4(BX, R11)
+	ADDQ $8, SI
+	SUBQ $2, CX
+	JMP  pairs32
+
+tail32:
+	TESTQ CX, CX
+	JZ    done32
+	MOVL  0(SI), AX
+	CMPL  AX, R8
+	ADCQ  $0, R10
+	COUNT4(AX, DI)
+
+done32:
+	TESTQ R10, R10
+	SETEQ sorted+32(FP)
+	RET
+
+// func scatter64(from, to []uint64, offset *[256]int, shift uint)
+TEXT ·scatter64(SB), NOSPLIT, $0-64
+	MOVQ from_base+0(FP), SI
+	MOVQ from_len+8(FP), BX
+	MOVQ to_base+24(FP), DI
+	MOVQ offset+48(FP), R8
+	MOVQ shift+56(FP), CX
+	TESTQ BX, BX
+	JZ    done
+
+loop64:
+	MOVQ    0(SI), AX
+	MOVQ    AX, DX
+	SHRQ    CX, DX
+	MOVBQZX DX, DX
+	MOVQ    0(R8)(DX*8), R9
+	MOVQ    AX, 0(DI)(R9*8)
+	INCQ    R9
+	MOVQ    R9, 0(R8)(DX*8)
+	ADDQ    $8, SI
+	DECQ    BX
+	JNZ     loop64
+
+done:
+	RET
+
+// func scatter32(from, to []uint32, offset *[256]int, shift uint)
+TEXT ·scatter32(SB), NOSPLIT, $0-64
+	MOVQ from_base+0(FP), SI
+	MOVQ from_len+8(FP), BX
+	MOVQ to_base+24(FP), DI
+	MOVQ offset+48(FP), R8
+	MOVQ shift+56(FP), CX
+	TESTQ BX, BX
+	JZ    done
+
+loop32:
+	MOVL    0(SI), AX
+	MOVL    AX, DX
+	SHRL    CX, DX
+	MOVBQZX DX, DX
+	MOVQ    0(R8)(DX*8), R9
+	MOVL    AX, 0(DI)(R9*4)
+	INCQ    R9
+	MOVQ    R9, 0(R8)(DX*8)
+	ADDQ    $4, SI
+	DECQ    BX
+	JNZ     loop32
+
+done:
+	RET
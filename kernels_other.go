@@ -0,0 +1,48 @@
+//go:build !amd64 || purego
+
+package zermelo
+
+// haveKernels is false as there are no assembly kernels for this platform. The Go kernels below are only tested.
+const haveKernels = false
+
+// countBytes64 counts every byte of each element of x, even elements into counts[0] and odd into counts[1].
+// It returns true if x is sorted.
+func countBytes64(x []uint64, counts *[2][8][256]uint32) (sorted bool) {
+	var prev uint64
+	sorted = true
+	for i, elem := range x {
+		c := &counts[i&1]
+		for p := range c {
+			c[p][uint8(elem>>(8*p))]++
+		}
+		sorted = sorted && elem >= prev
+		prev = elem
+	}
+	return sorted
+}
+
+// countBytes32 is countBytes64 for []uint32.
+func countBytes32(x []uint32, counts *[2][4][256]uint32) (sorted bool) {
+	var prev uint32
+	sorted = true
+	for i, elem := range x {
+		c := &counts[i&1]
+		for p := range c {
+			c[p][uint8(elem>>(8*p))]++
+		}
+		sorted = sorted && elem >= prev
+		prev = elem
+	}
+	return sorted
+}
+
+// scatter64 copies each element of from to to[offset[key]], where key is its byte shifted right by shift bits,
+// incrementing offset[key]. Offsets must be in range for to, as they are when found from the counts of from.
+func scatter64(from, to []uint64, offset *[256]int, shift uint) {
+	scatterUint64(from, to, offset, shift)
+}
+
+// scatter32 is scatter64 for []uint32.
+func scatter32(from, to []uint32, offset *[256]int, shift uint) {
+	scatterUint32(from, to, offset, shift)
+}
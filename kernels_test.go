@@ -0,0 +1,82 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestCountBytes(t *testing.T) {
+	testCountBytes[uint64](t, internal.RandInteger[uint64](), countBytes64)
+	testCountBytes[uint32](t, internal.RandInteger[uint32](), countBytes32)
+	testCountBytes[uint64](t, func() uint64 { return 7 }, countBytes64)
+}
+
+func testCountBytes[U uint32 | uint64, C [4][256]uint32 | [8][256]uint32](t *testing.T, gen func() U,
+	kernel func([]U, *[2]C) bool) {
+	size, _ := internal.Detect[U]()
+	for _, n := range []int{0, 1, 2, 3, 1000, 1001} {
+		x := make([]U, n)
+		internal.FillSlice(x, gen)
+		for _, sorted := range []bool{false, true} {
+			if sorted {
+				slices.Sort(x)
+			}
+			expected := make([][256]int, size/8)
+			expectSorted := slices.IsSorted(x)
+			for _, elem := range x {
+				for p := range expected {
+					expected[p][uint8(elem>>(8*p))]++
+				}
+			}
+			var c [2]C
+			gotSorted := kernel(x, &c)
+			got := make([][256]int, size/8)
+			for set := range c {
+				for p := range got {
+					for key, count := range c[set][p] {
+						got[p][key] += int(count)
+					}
+				}
+			}
+			if gotSorted != expectSorted {
+				t.Fatalf("%T: kernel found sorted %v for %d elements, expected %v", U(0), gotSorted, n, expectSorted)
+			}
+			if !slices.Equal(expected, got) {
+				t.Fatalf("%T: wrong kernel counts for %d elements", U(0), n)
+			}
+		}
+	}
+}
+
+func TestScatter(t *testing.T) {
+	testScatter[uint64](t, scatter64)
+	testScatter[uint32](t, scatter32)
+}
+
+func testScatter[U uint32 | uint64](t *testing.T, kernel func(from, to []U, offset *[256]int, shift uint)) {
+	size, _ := internal.Detect[U]()
+	for _, n := range []int{0, 1, 1000} {
+		from := make([]U, n)
+		internal.FillSlice(from, internal.RandInteger[U]())
+		for shift := uint(0); shift < size; shift += radix {
+			var offset [256]int
+			for _, elem := range from {
+				offset[uint8(elem>>shift)]++
+			}
+			bucketOffsets(&offset, false)
+			expectedOffset := offset
+			expected := make([]U, n)
+			for _, elem := range from {
+				key := uint8(elem >> shift)
+				expected[expectedOffset[key]] = elem
+				expectedOffset[key]++
+			}
+			to := make([]U, n)
+			kernel(from, to, &offset, shift)
+			if !slices.Equal(expected, to) || offset != expectedOffset {
+				t.Fatalf("%T: wrong kernel scatter of %d elements at %d", U(0), n, shift)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+)
+
+// RadixKeyer is implemented by types that SortKeyed can radix sort. Each value has a key of KeyLen bytes, and values
+// are ordered by comparing their keys byte by byte, with a key that is a prefix of another ordered first.
+// Keys are compared as unsigned bytes, so fixed size integers must be written big endian, with the sign bit of signed
+// integers flipped.
+type RadixKeyer interface {
+	// KeyLen returns the length of the key in bytes.
+	KeyLen() int
+	// KeyByte returns byte i of the key, for i from 0 up to KeyLen.
+	KeyByte(i int) byte
+}
+
+// keyedCutoff is the shortest slice, or partition of one, that SortKeyed radix sorts rather than comparison sorts.
+const keyedCutoff = 64
+
+// SortKeyed sorts x by the keys of its elements, see RadixKeyer, keeping elements with equal keys in their original
+// order. Slices are radix sorted most significant byte first, using a buffer borrowed from a pool, and partitions
+// shorter than a cutoff are comparison sorted from the byte they differ at.
+func SortKeyed[T RadixKeyer](x []T) {
+	if len(x) < keyedCutoff {
+		sortKeyedStable(x, 0)
+		return
+	}
+	buf, _ := internal.GetBuffer[T](len(x))
+	keys, _ := internal.GetBuffer[uint16](len(x))
+	msdKeyed(x, (*buf)[:len(x)], (*keys)[:len(x)], 0)
+	clear((*buf)[:len(x)]) // Do not keep elements alive from the pool
+	internal.PutBuffer(buf)
+	internal.PutBuffer(keys)
+}
+
+// msdKeyed sorts x, whose keys are all equal before byte depth, by byte depth and on, using buf and keys as scratch.
+// Each element's byte is read once per level and kept in keys, as one more than the byte, or zero past the key's end.
+func msdKeyed[T RadixKeyer](x, buf []T, keys []uint16, depth int) {
+	var counts [257]int
+	for ; ; depth++ { // Skip bytes that all keys share
+		if len(x) < keyedCutoff {
+			sortKeyedStable(x, depth)
+			return
+		}
+		clear(counts[:])
+		for i, elem := range x {
+			var key uint16 // past the end of the key
+			if depth < elem.KeyLen() {
+				key = uint16(elem.KeyByte(depth)) + 1
+			}
+			keys[i] = key
+			counts[key]++
+		}
+		if counts[keys[0]] != len(x) {
+			break
+		}
+		if keys[0] == 0 { // All keys are equal
+			return
+		}
+	}
+
+	var ends [257]int // of each bucket, once scattered
+	watermark := 0
+	for key, count := range counts {
+		counts[key] = watermark
+		watermark += count
+		ends[key] = watermark
+	}
+	for i, elem := range x {
+		key := keys[i]
+		buf[counts[key]] = elem
+		counts[key]++
+	}
+	copy(x, buf)
+
+	start := ends[0] // Keys that ended are equal, and already in order
+	for _, end := range ends[1:] {
+		if end-start > 1 {
+			msdKeyed(x[start:end], buf[start:end], keys[start:end], depth+1)
+		}
+		start = end
+	}
+}
+
+// sortKeyedStable sorts x, whose keys are all equal before byte depth, with a stable comparison sort.
+func sortKeyedStable[T RadixKeyer](x []T, depth int) {
+	slices.SortStableFunc(x, func(a, b T) int {
+		return compareKeys(a, b, depth)
+	})
+}
+
+// compareKeys compares the keys of a and b from byte depth on, returning -1, 0 or 1 like cmp.Compare.
+func compareKeys[T RadixKeyer](a, b T, depth int) int {
+	alen, blen := a.KeyLen(), b.KeyLen()
+	for i := depth; i < alen && i < blen; i++ {
+		if ab, bb := a.KeyByte(i), b.KeyByte(i); ab != bb {
+			if ab < bb {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case alen < blen:
+		return -1
+	case alen > blen:
+		return 1
+	}
+	return 0
+}
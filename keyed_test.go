@@ -0,0 +1,53 @@
+package zermelo
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// testKey is a RadixKeyer with a byte key and the index it was created at, to check stability.
+type testKey struct {
+	key   []byte
+	index int
+}
+
+func (k testKey) KeyLen() int        { return len(k.key) }
+func (k testKey) KeyByte(i int) byte { return k.key[i] }
+
+func TestSortKeyed(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		n      int
+		maxLen int
+		prefix string
+		alpha  int // distinct byte values
+	}{
+		{"empty", 0, 4, "", 256},
+		{"short", keyedCutoff - 1, 8, "", 256},
+		{"random", testSize, 8, "", 256},
+		{"narrow", testSize, 12, "", 3},
+		{"prefixed", testSize, 6, "common prefix/", 4},
+		{"empty keys", testSize, 0, "", 1},
+	} {
+		x := make([]testKey, tc.n)
+		for i := range x {
+			key := []byte(tc.prefix)
+			for j := rand.Intn(tc.maxLen + 1); j > 0; j-- {
+				key = append(key, byte(rand.Intn(tc.alpha)))
+			}
+			x[i] = testKey{key: key, index: i}
+		}
+		control := slices.Clone(x)
+		slices.SortStableFunc(control, func(a, b testKey) int {
+			return bytes.Compare(a.key, b.key)
+		})
+		SortKeyed(x)
+		for i := range x {
+			if !bytes.Equal(control[i].key, x[i].key) || control[i].index != x[i].index {
+				t.Fatalf("%s: sort failed at %d, expected %v, got %v", tc.name, i, control[i], x[i])
+			}
+		}
+	}
+}
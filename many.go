@@ -0,0 +1,14 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// SortMany sorts each slice in xs, using up to workers goroutines, or GOMAXPROCS if workers is not positive.
+// Each goroutine reuses the buffer of its own Sorter, and slices below the cutoff are sorted with SmallSort.
+// The slices in xs must not overlap.
+func SortMany[T Integer](xs [][]T, workers int) {
+	internal.SortMany(xs, workers, func() internal.Sorter[T] {
+		return NewSorter[T]()
+	})
+}
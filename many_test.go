@@ -0,0 +1,33 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortMany(t *testing.T) {
+	testSortMany[uint64](t, 0)
+	testSortMany[int32](t, 1)
+	testSortMany[int8](t, 3)
+	testSortMany[uint](t, 1000)
+	SortMany[int]([][]int{}, 0)
+}
+
+func testSortMany[I Integer](t *testing.T, workers int) {
+	xs := make([][]I, 100)
+	controls := make([][]I, len(xs))
+	rng := internal.RandInteger[I]()
+	for i := range xs {
+		xs[i] = make([]I, i*testSize/len(xs))
+		internal.FillSlice(xs[i], rng)
+		controls[i] = slices.Clone(xs[i])
+		slices.Sort(controls[i])
+	}
+	SortMany(xs, workers)
+	for i := range xs {
+		if !slices.Equal(xs[i], controls[i]) {
+			t.Fatalf("%T: workers=%d, slice %d not sorted", I(0), workers, i)
+		}
+	}
+}
@@ -0,0 +1,546 @@
+// Code generated by go run ./internal/gen. DO NOT EDIT.
+
+package zermelo
+
+// sortNetwork sorts x with a sorting network if it has at most 16 elements, returning false if it is longer.
+func sortNetwork[T Integer](x []T) bool {
+	switch len(x) {
+	case 0, 1:
+	case 2:
+		network2(x)
+	case 3:
+		network3(x)
+	case 4:
+		network4(x)
+	case 5:
+		network5(x)
+	case 6:
+		network6(x)
+	case 7:
+		network7(x)
+	case 8:
+		network8(x)
+	case 9:
+		network9(x)
+	case 10:
+		network10(x)
+	case 11:
+		network11(x)
+	case 12:
+		network12(x)
+	case 13:
+		network13(x)
+	case 14:
+		network14(x)
+	case 15:
+		network15(x)
+	case 16:
+		network16(x)
+	default:
+		return false
+	}
+	return true
+}
+
+// network2 sorts 2 elements with 1 branchless compare-exchanges.
+func network2[T Integer](x []T) {
+	_ = x[2-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+}
+
+// network3 sorts 3 elements with 3 branchless compare-exchanges.
+func network3[T Integer](x []T) {
+	_ = x[3-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+}
+
+// network4 sorts 4 elements with 5 branchless compare-exchanges.
+func network4[T Integer](x []T) {
+	_ = x[4-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+}
+
+// network5 sorts 5 elements with 9 branchless compare-exchanges.
+func network5[T Integer](x []T) {
+	_ = x[5-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+}
+
+// network6 sorts 6 elements with 12 branchless compare-exchanges.
+func network6[T Integer](x []T) {
+	_ = x[6-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+}
+
+// network7 sorts 7 elements with 16 branchless compare-exchanges.
+func network7[T Integer](x []T) {
+	_ = x[7-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+}
+
+// network8 sorts 8 elements with 19 branchless compare-exchanges.
+func network8[T Integer](x []T) {
+	_ = x[8-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+}
+
+// network9 sorts 9 elements with 28 branchless compare-exchanges.
+func network9[T Integer](x []T) {
+	_ = x[9-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+}
+
+// network10 sorts 10 elements with 32 branchless compare-exchanges.
+func network10[T Integer](x []T) {
+	_ = x[10-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+}
+
+// network11 sorts 11 elements with 38 branchless compare-exchanges.
+func network11[T Integer](x []T) {
+	_ = x[11-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+}
+
+// network12 sorts 12 elements with 42 branchless compare-exchanges.
+func network12[T Integer](x []T) {
+	_ = x[12-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[10], x[11] = min(x[10], x[11]), max(x[10], x[11])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[9], x[11] = min(x[9], x[11]), max(x[9], x[11])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[3], x[11] = min(x[3], x[11]), max(x[3], x[11])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[7], x[11] = min(x[7], x[11]), max(x[7], x[11])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+}
+
+// network13 sorts 13 elements with 48 branchless compare-exchanges.
+func network13[T Integer](x []T) {
+	_ = x[13-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[10], x[11] = min(x[10], x[11]), max(x[10], x[11])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[9], x[11] = min(x[9], x[11]), max(x[9], x[11])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[8], x[12] = min(x[8], x[12]), max(x[8], x[12])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[3], x[11] = min(x[3], x[11]), max(x[3], x[11])
+	x[4], x[12] = min(x[4], x[12]), max(x[4], x[12])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[7], x[11] = min(x[7], x[11]), max(x[7], x[11])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+}
+
+// network14 sorts 14 elements with 53 branchless compare-exchanges.
+func network14[T Integer](x []T) {
+	_ = x[14-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[10], x[11] = min(x[10], x[11]), max(x[10], x[11])
+	x[12], x[13] = min(x[12], x[13]), max(x[12], x[13])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[9], x[11] = min(x[9], x[11]), max(x[9], x[11])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[8], x[12] = min(x[8], x[12]), max(x[8], x[12])
+	x[9], x[13] = min(x[9], x[13]), max(x[9], x[13])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[3], x[11] = min(x[3], x[11]), max(x[3], x[11])
+	x[4], x[12] = min(x[4], x[12]), max(x[4], x[12])
+	x[5], x[13] = min(x[5], x[13]), max(x[5], x[13])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[7], x[11] = min(x[7], x[11]), max(x[7], x[11])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+}
+
+// network15 sorts 15 elements with 59 branchless compare-exchanges.
+func network15[T Integer](x []T) {
+	_ = x[15-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[10], x[11] = min(x[10], x[11]), max(x[10], x[11])
+	x[12], x[13] = min(x[12], x[13]), max(x[12], x[13])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[9], x[11] = min(x[9], x[11]), max(x[9], x[11])
+	x[12], x[14] = min(x[12], x[14]), max(x[12], x[14])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[8], x[12] = min(x[8], x[12]), max(x[8], x[12])
+	x[9], x[13] = min(x[9], x[13]), max(x[9], x[13])
+	x[10], x[14] = min(x[10], x[14]), max(x[10], x[14])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[3], x[11] = min(x[3], x[11]), max(x[3], x[11])
+	x[4], x[12] = min(x[4], x[12]), max(x[4], x[12])
+	x[5], x[13] = min(x[5], x[13]), max(x[5], x[13])
+	x[6], x[14] = min(x[6], x[14]), max(x[6], x[14])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[7], x[11] = min(x[7], x[11]), max(x[7], x[11])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+}
+
+// network16 sorts 16 elements with 63 branchless compare-exchanges.
+func network16[T Integer](x []T) {
+	_ = x[16-1]
+	x[0], x[1] = min(x[0], x[1]), max(x[0], x[1])
+	x[2], x[3] = min(x[2], x[3]), max(x[2], x[3])
+	x[4], x[5] = min(x[4], x[5]), max(x[4], x[5])
+	x[6], x[7] = min(x[6], x[7]), max(x[6], x[7])
+	x[8], x[9] = min(x[8], x[9]), max(x[8], x[9])
+	x[10], x[11] = min(x[10], x[11]), max(x[10], x[11])
+	x[12], x[13] = min(x[12], x[13]), max(x[12], x[13])
+	x[14], x[15] = min(x[14], x[15]), max(x[14], x[15])
+	x[0], x[2] = min(x[0], x[2]), max(x[0], x[2])
+	x[1], x[3] = min(x[1], x[3]), max(x[1], x[3])
+	x[4], x[6] = min(x[4], x[6]), max(x[4], x[6])
+	x[5], x[7] = min(x[5], x[7]), max(x[5], x[7])
+	x[8], x[10] = min(x[8], x[10]), max(x[8], x[10])
+	x[9], x[11] = min(x[9], x[11]), max(x[9], x[11])
+	x[12], x[14] = min(x[12], x[14]), max(x[12], x[14])
+	x[13], x[15] = min(x[13], x[15]), max(x[13], x[15])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+	x[0], x[4] = min(x[0], x[4]), max(x[0], x[4])
+	x[1], x[5] = min(x[1], x[5]), max(x[1], x[5])
+	x[2], x[6] = min(x[2], x[6]), max(x[2], x[6])
+	x[3], x[7] = min(x[3], x[7]), max(x[3], x[7])
+	x[8], x[12] = min(x[8], x[12]), max(x[8], x[12])
+	x[9], x[13] = min(x[9], x[13]), max(x[9], x[13])
+	x[10], x[14] = min(x[10], x[14]), max(x[10], x[14])
+	x[11], x[15] = min(x[11], x[15]), max(x[11], x[15])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+	x[0], x[8] = min(x[0], x[8]), max(x[0], x[8])
+	x[1], x[9] = min(x[1], x[9]), max(x[1], x[9])
+	x[2], x[10] = min(x[2], x[10]), max(x[2], x[10])
+	x[3], x[11] = min(x[3], x[11]), max(x[3], x[11])
+	x[4], x[12] = min(x[4], x[12]), max(x[4], x[12])
+	x[5], x[13] = min(x[5], x[13]), max(x[5], x[13])
+	x[6], x[14] = min(x[6], x[14]), max(x[6], x[14])
+	x[7], x[15] = min(x[7], x[15]), max(x[7], x[15])
+	x[4], x[8] = min(x[4], x[8]), max(x[4], x[8])
+	x[5], x[9] = min(x[5], x[9]), max(x[5], x[9])
+	x[6], x[10] = min(x[6], x[10]), max(x[6], x[10])
+	x[7], x[11] = min(x[7], x[11]), max(x[7], x[11])
+	x[2], x[4] = min(x[2], x[4]), max(x[2], x[4])
+	x[3], x[5] = min(x[3], x[5]), max(x[3], x[5])
+	x[6], x[8] = min(x[6], x[8]), max(x[6], x[8])
+	x[7], x[9] = min(x[7], x[9]), max(x[7], x[9])
+	x[10], x[12] = min(x[10], x[12]), max(x[10], x[12])
+	x[11], x[13] = min(x[11], x[13]), max(x[11], x[13])
+	x[1], x[2] = min(x[1], x[2]), max(x[1], x[2])
+	x[3], x[4] = min(x[3], x[4]), max(x[3], x[4])
+	x[5], x[6] = min(x[5], x[6]), max(x[5], x[6])
+	x[7], x[8] = min(x[7], x[8]), max(x[7], x[8])
+	x[9], x[10] = min(x[9], x[10]), max(x[9], x[10])
+	x[11], x[12] = min(x[11], x[12]), max(x[11], x[12])
+	x[13], x[14] = min(x[13], x[14]), max(x[13], x[14])
+}
@@ -0,0 +1,150 @@
+package zermelo
+
+import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"runtime/trace"
+	"time"
+)
+
+// Algorithm is a sorting algorithm used to sort a slice.
+type Algorithm int
+
+const (
+	// Comparison is comparison sort, using SmallSort for integers, slices.Sort for floats, or a SmallSorter.
+	Comparison Algorithm = iota
+	// Radix is LSD radix sort.
+	Radix
+	// Counting is counting sort, used for 8 bit elements and many 16 bit elements. It needs no buffer.
+	Counting
+	// Merge is merging a few sorted runs, reversing descending ones first, used for slices that are already sorted,
+	// sorted in reverse, or made of a few sorted runs. It needs a buffer only if there is more than one run.
+	Merge
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case Comparison:
+		return "comparison"
+	case Radix:
+		return "radix"
+	case Counting:
+		return "counting"
+	case Merge:
+		return "merge"
+	}
+	return "unknown"
+}
+
+// Stats describes one sort, as passed to an Observer.
+type Stats struct {
+	// Algorithm is the algorithm used.
+	Algorithm Algorithm
+	// Len is the length of the slice sorted.
+	Len int
+	// Size is the size of each element in bits.
+	Size uint
+	// Passes is the number of radix sort passes run over the slice.
+	Passes int
+	// SkippedPasses is the number of radix sort passes not run, either because the slice was found to be sorted
+	// or because every element had the same byte for that pass. Sorted slices are usually found before radix sort,
+	// and sorted by Merge instead.
+	SkippedPasses int
+	// Allocs is the number of buffers allocated for the sort.
+	Allocs int
+	// AllocBytes is the total size of the buffers allocated for the sort.
+	AllocBytes int
+	// Elapsed is how long the sort took.
+	Elapsed time.Duration
+	// Err is the error returned if the sort was cancelled, see ContextSorter.
+	Err error
+}
+
+// Observer is called with Stats after each sort it observes.
+// An Observer set with SetObserver or shared by Sorters must be safe for concurrent use.
+type Observer func(Stats)
+
+// SetObserver sets an Observer called after every Sort, SortContext and SortBYOB, and every sort by any Sorter,
+// including those in the floats package. Nil removes it. Sorters created with WithObserver call both observers.
+//
+// While an execution trace is running, each sort is also marked as a runtime/trace region, named
+// "zermelo.radix", "zermelo.counting", "zermelo.merge" or "zermelo.comparison", whether or not any Observer is set.
+func SetObserver(o Observer) {
+	internal.SetObserver(o)
+}
+
+// WithObserver makes the Sorter call o after each sort.
+func WithObserver(o Observer) Option {
+	return func(opts *internal.Options) {
+		opts.Observer = o
+	}
+}
+
+// observation tracks one sort for observers and execution traces. A nil observation observes nothing.
+type observation struct {
+	local  Observer
+	global Observer
+	region *trace.Region
+	start  time.Time
+	stats  Stats
+}
+
+// startObserving returns an observation of a sort of n elements of the given size, or nil if there is no observer
+// and no execution trace running.
+func startObserving(ctx context.Context, local Observer, alg Algorithm, n int, size uint) *observation {
+	global, _ := internal.LoadObserver().(Observer)
+	tracing := trace.IsEnabled()
+	if local == nil && global == nil && !tracing {
+		return nil
+	}
+	ob := &observation{
+		local:  local,
+		global: global,
+		stats:  Stats{Algorithm: alg, Len: n, Size: size},
+	}
+	if tracing {
+		ob.region = trace.StartRegion(ctx, "zermelo."+alg.String())
+	}
+	ob.start = time.Now()
+	return ob
+}
+
+// alloc records the allocation of a buffer of the given size in bytes.
+func (ob *observation) alloc(bytes int) {
+	if ob != nil {
+		ob.stats.Allocs++
+		ob.stats.AllocBytes += bytes
+	}
+}
+
+// passFunc returns a passFunc that counts radix sort passes, or nil if ob is nil.
+func (ob *observation) passFunc() passFunc {
+	if ob == nil {
+		return nil
+	}
+	return func(int) error {
+		ob.stats.Passes++
+		return nil
+	}
+}
+
+// finish completes the observation of a sort with the given total radix passes, calling observers.
+func (ob *observation) finish(passes int, err error) {
+	if ob == nil {
+		return
+	}
+	ob.stats.Elapsed = time.Since(ob.start)
+	if ob.region != nil {
+		ob.region.End()
+	}
+	ob.stats.Err = err
+	if err == nil && ob.stats.Algorithm == Radix {
+		ob.stats.SkippedPasses = passes - ob.stats.Passes
+	}
+	if ob.local != nil {
+		ob.local(ob.stats)
+	}
+	if ob.global != nil {
+		ob.global(ob.stats)
+	}
+}
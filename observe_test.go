@@ -0,0 +1,107 @@
+package zermelo
+
+import (
+	"context"
+	"errors"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"io"
+	"runtime/trace"
+	"slices"
+	"testing"
+)
+
+func TestWithObserver(t *testing.T) {
+	var stats []Stats
+	test := NewSorterWith[uint64](WithObserver(func(s Stats) {
+		stats = append(stats, s)
+	}))
+	sortAndCheck[uint64](t, test, compSortCutoff64-1)
+	sortAndCheck[uint64](t, test, testSize)
+	sortAndCheck[uint64](t, test, testSize)
+	test.Sort(make([]uint64, testSize))
+	narrow := make([]uint64, testSize)
+	internal.FillSlice(narrow, internal.RandInteger[uint64]())
+	for i := range narrow {
+		narrow[i] &= 0xff00ff
+	}
+	test.Sort(narrow)
+	if len(stats) != 5 {
+		t.Fatal("wrong number of observations", stats)
+	}
+	expected := []Stats{
+		{Algorithm: Comparison, Len: compSortCutoff64 - 1, Size: 64},
+		{Algorithm: Radix, Len: testSize, Size: 64, Passes: 8, Allocs: 1, AllocBytes: 8 * testSize},
+		{Algorithm: Radix, Len: testSize, Size: 64, Passes: 8},
+		{Algorithm: Merge, Len: testSize, Size: 64}, // already sorted
+		{Algorithm: Radix, Len: testSize, Size: 64, Passes: 2, SkippedPasses: 6},
+	}
+	for i, s := range stats {
+		if s.Elapsed <= 0 {
+			t.Fatal("elapsed not measured", s)
+		}
+		s.Elapsed = 0
+		if s != expected[i] {
+			t.Fatalf("wrong stats %d, expected %+v, got %+v", i, expected[i], s)
+		}
+	}
+
+	stats = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	x := make([]uint64, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint64]())
+	if err := test.(ContextSorter[uint64]).SortContext(ctx, x); err == nil {
+		t.Fatal("sort not cancelled")
+	}
+	if len(stats) != 0 {
+		t.Fatal("sort cancelled before start was observed", stats)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	test = NewSorterWith[uint64](WithProgress(func(int, int) { cancel() }), WithObserver(func(s Stats) {
+		stats = append(stats, s)
+	}))
+	if err := test.(ContextSorter[uint64]).SortContext(ctx, x); len(stats) != 1 ||
+		!errors.Is(stats[0].Err, context.Canceled) || stats[0].Passes != 1 || stats[0].SkippedPasses != 0 {
+		t.Fatal("wrong stats for cancelled sort", err, stats)
+	}
+}
+
+func TestSetObserver(t *testing.T) {
+	defer SetObserver(nil)
+	var stats []Stats
+	SetObserver(func(s Stats) {
+		stats = append(stats, s)
+	})
+	x := make([]int16, testSize)
+	internal.FillSlice(x, internal.RandInteger[int16]())
+	Sort(slices.Clone(x))
+	SortBYOB(slices.Clone(x), make([]int16, testSize))
+	Sort(x[:10])
+	NewSorter[int16]().Sort(x)
+	if len(stats) != 4 || stats[0].Algorithm != Radix || stats[0].Passes != 2 || stats[1].Allocs != 0 ||
+		stats[2].Algorithm != Comparison || stats[3].Allocs != 1 {
+		t.Fatal("wrong global observations", stats)
+	}
+
+	SetObserver(nil)
+	Sort(x)
+	if len(stats) != 4 {
+		t.Fatal("removed observer called")
+	}
+}
+
+func TestTraceRegions(t *testing.T) {
+	if err := trace.Start(io.Discard); err != nil {
+		t.Skip("tracing unavailable:", err)
+	}
+	defer trace.Stop()
+	testSort[uint32](t, internal.RandInteger[uint32](), false)
+	testSorter[uint32](t, internal.RandInteger[uint32](), true)
+}
+
+func TestAlgorithmString(t *testing.T) {
+	if Comparison.String() != "comparison" || Radix.String() != "radix" || Counting.String() != "counting" ||
+		Merge.String() != "merge" || Algorithm(-1).String() != "unknown" {
+		t.Fatal("wrong algorithm names")
+	}
+}
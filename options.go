@@ -0,0 +1,82 @@
+package zermelo
+
+import (
+	"cmp"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// Option configures a Sorter created with NewSorterWith.
+type Option func(*internal.Options)
+
+// WithCutoff sets the slice length below which the Sorter uses its SmallSorter instead of radix sort.
+// Zero means always use radix sort. Without this option, the process-wide cutoff from SetCutoff is used.
+func WithCutoff(cutoff int) Option {
+	return func(o *internal.Options) {
+		o.Cutoff = max(cutoff, 0)
+	}
+}
+
+// WithGrowthFactor sets how much larger than needed the buffer is made when it must grow, 1.25 by default.
+// A factor of 1 allocates exactly what each sort needs. Factors less than 1 are treated as 1.
+// The first buffer allocated is always exactly the size needed.
+func WithGrowthFactor(factor float64) Option {
+	return func(o *internal.Options) {
+		o.Growth = max(factor, 1)
+	}
+}
+
+// WithInitialCapacity makes the Sorter allocate a buffer for slices of up to n elements when it is created,
+// instead of on the first sort that needs one.
+func WithInitialCapacity(n int) Option {
+	return func(o *internal.Options) {
+		o.Capacity = max(n, 0)
+	}
+}
+
+// WithMaxRetained limits the buffer kept by the Sorter between sorts to n elements. Sorting a longer slice
+// allocates a buffer that is discarded afterward. Zero, the default, means no limit.
+func WithMaxRetained(n int) Option {
+	return func(o *internal.Options) {
+		o.MaxRetained = max(n, 0)
+	}
+}
+
+// WithDescending makes the Sorter sort slices in descending order.
+func WithDescending() Option {
+	return func(o *internal.Options) {
+		o.Descending = true
+	}
+}
+
+// WithParallelism allows the Sorter to use up to n goroutines to radix sort a single large slice.
+// The default is 1. Slices are only split when each goroutine has enough work to be worth starting.
+func WithParallelism(n int) Option {
+	return func(o *internal.Options) {
+		o.Parallelism = max(n, 1)
+	}
+}
+
+// WithProgress makes the Sorter call progress as each slice is sorted, with the steps done so far and the total.
+// For radix sort, each step is one pass over the slice. Comparison sort, counting sort and merging runs are one step.
+// The last call for each slice has done equal to total, unless the sort was cancelled, see ContextSorter.
+// Radix passes that would not move any element are skipped, so done may advance by more than one step between calls.
+func WithProgress(progress func(done, total int)) Option {
+	return func(o *internal.Options) {
+		o.Progress = progress
+	}
+}
+
+// SmallSorter sorts slices too short for radix sort, see WithSmallSorter.
+type SmallSorter[T cmp.Ordered] func(x []T)
+
+// WithSmallSorter makes the Sorter sort slices shorter than its cutoff with small, instead of SmallSort for integers
+// or slices.Sort for floats. It only applies to Sorters of T, and is ignored by Sorters of any other type.
+// Large slices partitioned before radix sort, see Plan, also have their partitions shorter than the cutoff sorted
+// by small. Sorts by small of whole slices are reported to observers as Comparison sorts.
+func WithSmallSorter[T cmp.Ordered](small SmallSorter[T]) Option {
+	return func(o *internal.Options) {
+		if small != nil {
+			o.SmallSorter = small
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestWithDescending(t *testing.T) {
+	testDescending[int32](t, internal.RandInteger[int32](), NewSorterWith[int32](WithDescending()))
+	testDescending[uint64](t, internal.RandInteger[uint64](), NewSorterWith[uint64](WithDescending()))
+	testDescending[int64](t, internal.RandInteger[int64](), NewSorterWith[int64](WithDescending(), WithCutoff(0)))
+}
+
+func testDescending[I Integer](t *testing.T, gen func() I, test Sorter[I]) {
+	for i := 0; i <= testSize; i++ {
+		toTest := make([]I, i)
+		internal.FillSlice(toTest, gen)
+		control := slices.Clone(toTest)
+		slices.Sort(control)
+		slices.Reverse(control)
+		test.Sort(toTest)
+		if !slices.Equal(control, toTest) {
+			t.Fatal(control, toTest)
+		}
+	}
+}
+
+func TestWithBufferOptions(t *testing.T) {
+	s := newSorter[uint64](WithCutoff(0), WithInitialCapacity(100), WithGrowthFactor(2), WithMaxRetained(500))
+	test := s.(*sorter[uint64])
+	if len(test.buf) != 100 {
+		t.Fatal("wrong initial capacity", len(test.buf))
+	}
+	sortAndCheck(t, test, 100)
+	if len(test.buf) != 100 {
+		t.Fatal("buffer grew when large enough", len(test.buf))
+	}
+	sortAndCheck(t, test, 200)
+	if len(test.buf) != 400 {
+		t.Fatal("buffer did not grow by factor", len(test.buf))
+	}
+	sortAndCheck(t, test, 401)
+	if len(test.buf) != 500 {
+		t.Fatal("buffer growth not limited to max retained", len(test.buf))
+	}
+	sortAndCheck(t, test, 1000)
+	if len(test.buf) != 500 {
+		t.Fatal("buffer over max retained was kept", len(test.buf))
+	}
+
+	capped := newSorter[uint64](WithInitialCapacity(1000), WithMaxRetained(10)).(*sorter[uint64])
+	if len(capped.buf) != 10 {
+		t.Fatal("initial capacity not limited to max retained", len(capped.buf))
+	}
+}
+
+func sortAndCheck[I Integer](t *testing.T, test Sorter[I], n int) {
+	toTest := make([]I, n)
+	internal.FillSlice(toTest, internal.RandInteger[I]())
+	control := slices.Clone(toTest)
+	slices.Sort(control)
+	test.Sort(toTest)
+	if !slices.Equal(control, toTest) {
+		t.Fatal(control, toTest)
+	}
+}
+
+func TestWithParallelism(t *testing.T) {
+	testParallel[int8](t, internal.RandInteger[int8]())
+	testParallel[int32](t, internal.RandInteger[int32]())
+	testParallel[int64](t, internal.RandInteger[int64]())
+	testParallel[uint16](t, internal.RandInteger[uint16]())
+	testParallel[uint64](t, internal.RandInteger[uint64]())
+	testParallel[uint64](t, func() uint64 { return uint64(internal.RandInteger[uint8]()()) })
+	testParallel[int64](t, func() int64 { return int64(internal.RandInteger[int16]()()) })
+	testSorterWith[uint32](t, internal.RandInteger[uint32](), NewSorterWith[uint32](WithParallelism(4)))
+}
+
+func testParallel[I Integer](t *testing.T, gen func() I) {
+	test := NewSorterWith[I](WithParallelism(3))
+	for _, n := range []int{2*parallelMinChunk - 1, 3*parallelMinChunk + 7, 8 * parallelMinChunk} {
+		toTest := make([]I, n)
+		internal.FillSlice(toTest, gen)
+		control := slices.Clone(toTest)
+		slices.Sort(control)
+		test.Sort(toTest)
+		if !slices.Equal(control, toTest) {
+			t.Fatalf("%T: parallel sort of %d failed", I(0), n)
+		}
+		test.Sort(toTest) // presorted
+		if !slices.Equal(control, toTest) {
+			t.Fatalf("%T: parallel sort of %d sorted failed", I(0), n)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"sync"
+)
+
+// parallelMinChunk is the fewest elements each goroutine sorts in sortBYOBParallel.
+const parallelMinChunk = 1 << 14
+
+// sortBYOBParallel is sortBYOB, splitting the counting and scattering of each pass across up to parallelism
+// goroutines. Each goroutine owns a contiguous chunk and its own histogram, so scattering stays stable.
+// Digits are as wide as radixSort would use.
+// If pass is not nil, it is called after each pass, as for radixSort.
+func sortBYOBParallel[T Integer](x, buffer []T, size uint, minval T, short shortSort[T], parallelism int,
+	pass passFunc) error {
+	workers := min(parallelism, len(x)/parallelMinChunk)
+	if workers < 2 {
+		return radixSort(x, buffer, size, minval, short, pass)
+	}
+	if useHybrid(size, len(x)) {
+		return hybridSort(x, buffer, size, minval, 0, short, workers, pass)
+	}
+	return parallelRadixSort(x, buffer, size, minval, 0, workers, pass)
+}
+
+// parallelRadixSort is the radix sort of sortBYOBParallel, with workers goroutines. If floatBits is not zero,
+// elements are the bits of floats that wide, flipped by the first pass and unflipped by the last, see floatBitsSort.
+func parallelRadixSort[T Integer](x, buffer []T, size uint, minval T, floatBits uint, workers int,
+	pass passFunc) error {
+	chunk := (len(x) + workers - 1) / workers
+	workers = (len(x) + chunk - 1) / chunk
+	d := newDigits(size, digitBits(size, len(x)), minval != 0)
+	counts := make([][]int, workers)
+	sorted := make([]bool, workers)
+	pooled := make([]*[]int, workers+1)
+	defer func() {
+		for _, p := range pooled {
+			if p != nil {
+				internal.PutBuffer(p)
+			}
+		}
+	}()
+
+	// Count every digit of each chunk at once, as radixSort does
+	parallelDo(workers, func(w int) {
+		lo, hi := w*chunk, min((w+1)*chunk, len(x))
+		pooled[w], counts[w] = d.histograms()
+		sorted[w] = digitHistograms(x[lo:hi], counts[w], d, minval, floatBits)
+	})
+	if chunksSorted(x, sorted, chunk, floatBits) { // Short-circuit sorted
+		return nil
+	}
+	var total []int
+	pooled[workers], total = d.histograms()
+	for w := range counts {
+		for key, count := range counts[w] {
+			total[key] += count
+		}
+	}
+
+	var moving [maxPasses]int
+	return scatterPasses(x, buffer, movingPasses(&moving, d.passes, len(x), func(p int) []int {
+		return total[p*d.buckets() : (p+1)*d.buckets()]
+	}), floatBits, pass, func(from, to []T, p int, first, last bool) {
+		base := p * d.buckets()
+		shift := uint(p) * d.bits
+		if !first { // Chunk counts are only valid until elements move between chunks
+			parallelDo(workers, func(w int) {
+				lo, hi := w*chunk, min((w+1)*chunk, len(from))
+				countDigits(from[lo:hi], counts[w][base:base+d.buckets()], d, shift)
+			})
+		}
+
+		// Find target bucket offsets, per chunk within each bucket
+		var watermark int
+		for key := base; key < base+d.buckets(); key++ {
+			for w := range counts {
+				count := counts[w][key]
+				counts[w][key] = watermark
+				watermark += count
+			}
+		}
+
+		parallelDo(workers, func(w int) {
+			lo, hi := w*chunk, min((w+1)*chunk, len(from))
+			scatterDigits(from[lo:hi], to, counts[w][base:base+d.buckets()], d, shift, floatBits, first, last)
+		})
+	})
+}
+
+// countDigits replaces counts with the counts of each digit of x shifted right by shift bits.
+func countDigits[T Integer](x []T, counts []int, d digits, shift uint) {
+	clear(counts)
+	for _, elem := range x {
+		counts[d.key(uint64(elem), shift)]++
+	}
+}
+
+// chunksSorted returns true if every chunk of x is sorted and each chunk starts no lower than the last ended.
+// If floatBits is not zero, elements are compared as the bits of floats that wide.
+func chunksSorted[T Integer](x []T, sorted []bool, chunk int, floatBits uint) bool {
+	for w, ok := range sorted {
+		if !ok || (w > 0 && floatKey(x[w*chunk], floatBits) < floatKey(x[w*chunk-1], floatBits)) {
+			return false
+		}
+	}
+	return true
+}
+
+// parallelDo calls f(0) through f(n-1) in their own goroutines and waits for them all to return.
+func parallelDo(n int, f func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
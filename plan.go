@@ -0,0 +1,60 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// PlanInfo describes how a slice would be sorted, as returned by Plan.
+type PlanInfo struct {
+	// Size is the size of each element in bits.
+	Size uint
+	// Signed is true if elements are signed.
+	Signed bool
+	// Algorithm is the algorithm that would be used.
+	Algorithm Algorithm
+	// Partitioned is true if the slice would first be partitioned by the highest byte not shared by every element,
+	// then each partition sorted separately, as slices too large for the CPU cache are.
+	Partitioned bool
+	// DigitBits is the width in bits of the radix digits, one sorted per pass. Larger slices use wider digits.
+	// For partitioned slices, it is the width used for partitions holding an even share of the slice.
+	// Counting sort counts whole elements.
+	DigitBits uint
+	// Passes is the most radix sort passes that would be run. Fewer are run if the slice is found to be sorted,
+	// or if every element has the same byte for some pass. Partitioned slices count byte passes, whatever the
+	// width of the digits sorting partitions.
+	Passes int
+	// ScratchBytes is the size of the buffer needed, in bytes.
+	ScratchBytes int
+}
+
+// Plan describes how Sort would sort a slice of n elements of type T, without sorting anything.
+// It uses the process-wide cutoff for T, see SetCutoff, as Sort does.
+func Plan[T Integer](n int) PlanInfo {
+	size, minval := internal.Detect[T]()
+	if n < 2 || n < cutoffs.Get(size) {
+		return PlanInfo{Size: size, Signed: minval != 0, Algorithm: Comparison}
+	}
+	return PlanBYOB[T](n)
+}
+
+// PlanBYOB describes how SortBYOB would sort a slice of n elements of type T, without sorting anything.
+// ScratchBytes is the least buffer SortBYOB must be given, which is none if it would use counting sort.
+func PlanBYOB[T Integer](n int) PlanInfo {
+	size, minval := internal.Detect[T]()
+	if useCountingSort(size, n) {
+		return PlanInfo{Size: size, Signed: minval != 0, Algorithm: Counting, DigitBits: size, Passes: 1}
+	}
+	plan := PlanInfo{
+		Size:         size,
+		Signed:       minval != 0,
+		Algorithm:    Radix,
+		Partitioned:  useHybrid(size, n),
+		DigitBits:    digitBits(size, n),
+		Passes:       radixPasses(size, n),
+		ScratchBytes: n * int(size/8),
+	}
+	if plan.Partitioned { // Assuming evenly spread partition bytes, as few partitions are large enough for wide digits
+		plan.DigitBits = digitBits(size, n>>radix)
+	}
+	return plan
+}
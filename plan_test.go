@@ -0,0 +1,51 @@
+package zermelo
+
+import (
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	testPlan(t, Plan[uint8](compSortCutoff-1), PlanInfo{Size: 8, Algorithm: Comparison})
+	testPlan(t, Plan[int8](compSortCutoff), PlanInfo{Size: 8, Signed: true, Algorithm: Counting, DigitBits: 8,
+		Passes: 1})
+	testPlan(t, Plan[int32](1000), PlanInfo{Size: 32, Signed: true, Algorithm: Radix, DigitBits: 8, Passes: 4,
+		ScratchBytes: 4000})
+	testPlan(t, Plan[uint64](compSortCutoff64-1), PlanInfo{Size: 64, Algorithm: Comparison})
+	testPlan(t, Plan[uint64](1), PlanInfo{Size: 64, Algorithm: Comparison})
+	testPlan(t, PlanBYOB[uint64](10), PlanInfo{Size: 64, Algorithm: Radix, DigitBits: 8, Passes: 8, ScratchBytes: 80})
+	testPlan(t, Plan[int](1000), PlanInfo{Size: intSize, Signed: true, Algorithm: Radix, DigitBits: 8,
+		Passes: int(intSize / 8), ScratchBytes: 1000 * int(intSize/8)})
+	testPlan(t, PlanBYOB[uint64](hybridMin/8-1), PlanInfo{Size: 64, Algorithm: Radix, DigitBits: 8, Passes: 8,
+		ScratchBytes: hybridMin - 8})
+	testPlan(t, PlanBYOB[uint64](hybridMin/8), PlanInfo{Size: 64, Algorithm: Radix, Partitioned: true, DigitBits: 8,
+		Passes: 8, ScratchBytes: hybridMin})
+	testPlan(t, PlanBYOB[int32](hybridMin/4), PlanInfo{Size: 32, Signed: true, Algorithm: Radix, Partitioned: true,
+		DigitBits: 8, Passes: 4, ScratchBytes: hybridMin})
+	if intSize == 64 { // Partitions large enough for wide digits overflow ScratchBytes on 32 bit platforms
+		wide, widest := wideRadixMin, widestRadixMin
+		wide, widest = wide<<radix, widest<<radix // even partitions of wideRadixMin and widestRadixMin
+		testPlan(t, PlanBYOB[uint64](wide-1), PlanInfo{Size: 64, Algorithm: Radix, Partitioned: true,
+			DigitBits: 8, Passes: 8, ScratchBytes: 8 * (wide - 1)})
+		testPlan(t, PlanBYOB[uint64](wide), PlanInfo{Size: 64, Algorithm: Radix, Partitioned: true,
+			DigitBits: wideRadix, Passes: 8, ScratchBytes: 8 * wide})
+		testPlan(t, PlanBYOB[int32](wide), PlanInfo{Size: 32, Signed: true, Algorithm: Radix, Partitioned: true,
+			DigitBits: wideRadix, Passes: 4, ScratchBytes: 4 * wide})
+		testPlan(t, PlanBYOB[uint32](widest), PlanInfo{Size: 32, Algorithm: Radix, Partitioned: true,
+			DigitBits: widestRadix, Passes: 4, ScratchBytes: 4 * widest})
+	}
+	testPlan(t, PlanBYOB[uint8](hybridMin), PlanInfo{Size: 8, Algorithm: Counting, DigitBits: 8, Passes: 1})
+	testPlan(t, PlanBYOB[int16](countingSortMin16-1), PlanInfo{Size: 16, Signed: true, Algorithm: Radix, DigitBits: 8,
+		Passes: 2, ScratchBytes: 2 * (countingSortMin16 - 1)})
+	testPlan(t, PlanBYOB[int16](countingSortMin16), PlanInfo{Size: 16, Signed: true, Algorithm: Counting,
+		DigitBits: 16, Passes: 1})
+
+	defer SetCutoff[int16](compSortCutoff)
+	SetCutoff[int16](10)
+	testPlan(t, Plan[int16](10), PlanInfo{Size: 16, Signed: true, Algorithm: Radix, DigitBits: 8, Passes: 2, ScratchBytes: 20})
+}
+
+func testPlan(t *testing.T, got, expected PlanInfo) {
+	if got != expected {
+		t.Fatalf("wrong plan, expected %+v, got %+v", expected, got)
+	}
+}
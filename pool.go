@@ -0,0 +1,43 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// SetBufferPooling enables or disables the buffer pools used by Sort and floats.SortFloats, enabled by default.
+// With pooling, buffers are borrowed from size classed pools instead of allocated on every call, and returned
+// afterward. Disabling pooling makes each call allocate its own buffer, as a Sorter is not used.
+func SetBufferPooling(enabled bool) {
+	internal.SetPooling(enabled)
+}
+
+// SetMaxPooledBuffer sets the length, in elements, of the longest buffer kept in the pools used by Sort and
+// floats.SortFloats. Longer slices are sorted with buffers allocated only for that call. The default is 1<<20.
+func SetMaxPooledBuffer(n int) {
+	internal.SetMaxPooled(n)
+}
+
+// pooledBuffer borrows a buffer from the pools for one sort of elements that are size bits wide, when it turns out
+// to need one, and returns it with release.
+type pooledBuffer[T Integer] struct {
+	pooled *[]T
+	size   uint
+}
+
+// get borrows a buffer of at least n elements, recording an allocation in ob if none was pooled.
+func (p *pooledBuffer[T]) get(n int, ob *observation) []T {
+	buf, allocated := internal.GetBuffer[T](n)
+	if allocated {
+		ob.alloc(len(*buf) * int(p.size/8))
+	}
+	p.pooled = buf
+	return *buf
+}
+
+// release returns the buffer borrowed by get, if any, to the pools.
+func (p *pooledBuffer[T]) release() {
+	if p.pooled != nil {
+		internal.PutBuffer(p.pooled)
+		p.pooled = nil
+	}
+}
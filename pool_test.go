@@ -0,0 +1,30 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortPooled(t *testing.T) {
+	x := make([]uint64, 4*compSortCutoff64)
+	rng := internal.RandInteger[uint64]()
+	allocs := testing.AllocsPerRun(10, func() {
+		internal.FillSlice(x, rng)
+		Sort(x)
+	})
+	if allocs != 0 || !slices.IsSorted(x) {
+		t.Fatal("pooled sort allocated or failed", allocs)
+	}
+}
+
+func TestSetBufferPooling(t *testing.T) {
+	defer SetBufferPooling(true)
+	SetBufferPooling(false)
+	testSort[int64](t, internal.RandInteger[int64](), false)
+
+	defer SetMaxPooledBuffer(internal.DefaultMaxPooled)
+	SetBufferPooling(true)
+	SetMaxPooledBuffer(compSortCutoff64)
+	testSort[uint64](t, internal.RandInteger[uint64](), false)
+}
@@ -0,0 +1,102 @@
+package zermelo
+
+import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// rangeSpanPerElem is how many counts per element SortRange reads at most, beyond 256, before it sorts as Sort does.
+const rangeSpanPerElem = 2
+
+// lowSpanMax is the most values SortRange and lowSpanSort count, so that counts stay in cache however long the slice.
+const lowSpanMax = 1 << 16
+
+// SortRange sorts x, all of whose elements must be between min and max inclusive, with counting sort over the
+// max-min+1 possible values. This needs no buffer, only the counts, and is one read and one write of x.
+// If the range is too wide for counting to pay off compared to the length of x, or wider than 65536 values,
+// x is sorted as by Sort instead.
+// SortRange panics if max is less than min, or if an element of x is out of range.
+func SortRange[T Integer](x []T, min, max T) {
+	if max < min {
+		panic("zermelo: SortRange max is less than min")
+	}
+	if len(x) < 2 {
+		return
+	}
+	// Signed elements are sign extended, so the difference is right for any size, and zero if the range is all of
+	// uint64 after adding one
+	span := uint64(max) - uint64(min) + 1
+	if span == 0 || span > uint64(rangeSpanMax(len(x))) {
+		for _, elem := range x {
+			if elem < min || elem > max {
+				panic("zermelo: SortRange element out of range")
+			}
+		}
+		Sort(x)
+		return
+	}
+	size, _ := internal.Detect[T]()
+	ob := startObserving(context.Background(), nil, Counting, len(x), size)
+	counts, _ := internal.GetBuffer[int](int(span))
+	rangeCount(x, (*counts)[:span], min)
+	internal.PutBuffer(counts)
+	if pass := ob.passFunc(); pass != nil {
+		_ = pass(1)
+	}
+	ob.finish(1, nil)
+}
+
+// rangeSpanMax returns the widest span of values counted to sort n elements, by SortRange or lowSpanSort.
+func rangeSpanMax(n int) int {
+	return min(256+rangeSpanPerElem*n, lowSpanMax)
+}
+
+// rangeCount counts each value of x, offset from min, and rewrites x from the counts.
+func rangeCount[T Integer](x []T, counts []int, min T) {
+	clear(counts)
+	for _, elem := range x {
+		key := uint64(elem) - uint64(min)
+		if key >= uint64(len(counts)) {
+			panic("zermelo: SortRange element out of range")
+		}
+		counts[key]++
+	}
+	rewriteCounts(x, counts, min)
+}
+
+// lowSpan returns the smallest element of x and the number of values from it up to the largest, if they are close
+// enough for counting to pay off, as with status codes or small IDs, see lowSpanSort. Finding them stops as soon as
+// the span is too wide, which for most other slices is within the first few elements.
+func lowSpan[T Integer](x []T) (lo T, span int, ok bool) {
+	if len(x) < 2 {
+		return lo, 0, false
+	}
+	limit := uint64(rangeSpanMax(len(x)))
+	lo, hi := x[0], x[0]
+	for _, elem := range x[1:] {
+		switch {
+		case elem < lo:
+			lo = elem
+		case elem > hi:
+			hi = elem
+		default:
+			continue
+		}
+		if uint64(hi)-uint64(lo) >= limit {
+			return lo, 0, false
+		}
+	}
+	return lo, int(uint64(hi)-uint64(lo)) + 1, true
+}
+
+// lowSpanSort sorts x, whose elements are the span values from lo found by lowSpan, with counting sort as SortRange
+// does, calling pass after its one pass if it is not nil. This needs no buffer.
+func lowSpanSort[T Integer](x []T, lo T, span int, pass passFunc) error {
+	counts, _ := internal.GetBuffer[int](span)
+	rangeCount(x, (*counts)[:span], lo)
+	internal.PutBuffer(counts)
+	if pass != nil {
+		return pass(1)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortRange(t *testing.T) {
+	testSortRange[int64](t, -3, 500)
+	testSortRange[int64](t, 200, 599)
+	testSortRange[uint32](t, 7, 7)
+	testSortRange[uint64](t, 0, math.MaxUint64) // too wide, sorted as by Sort
+	testSortRange[int8](t, math.MinInt8, math.MaxInt8)
+	testSortRange[int8](t, -100, 100)
+	testSortRange[int16](t, math.MinInt16, math.MaxInt16)
+}
+
+func testSortRange[T Integer](t *testing.T, min, max T) {
+	for _, n := range []int{0, 1, 2, testSize} {
+		x := make([]T, n)
+		span := uint64(max) - uint64(min) + 1 // zero if the range is all of uint64
+		for i := range x {
+			x[i] = T(rand.Uint64())
+			if span != 0 {
+				x[i] = min + T(rand.Uint64()%span)
+			}
+		}
+		control := slices.Clone(x)
+		slices.Sort(control)
+		SortRange(x, min, max)
+		if !slices.Equal(control, x) {
+			t.Fatalf("%T: range sort of %d in [%v, %v] failed", T(0), n, min, max)
+		}
+	}
+}
+
+func TestSortRangeStats(t *testing.T) {
+	var stats []Stats
+	SetObserver(func(s Stats) {
+		stats = append(stats, s)
+	})
+	defer SetObserver(nil)
+	x := make([]int8, testSize)
+	internal.FillSlice(x, func() int8 { return int8(rand.Intn(201) - 100) })
+	SortRange(x, -100, 100) // wider than half of int8
+	if len(stats) != 1 || stats[0].Algorithm != Counting || stats[0].Passes != 1 {
+		t.Fatal("wrong stats", stats)
+	}
+
+	// Too many counts to stay in cache, however long the slice
+	stats = nil
+	wide := make([]uint32, 4*lowSpanMax)
+	internal.FillSlice(wide, func() uint32 { return uint32(rand.Intn(lowSpanMax + 1)) })
+	wide[0], wide[1] = 0, lowSpanMax
+	SortRange(wide, 0, lowSpanMax)
+	if !slices.IsSorted(wide) || len(stats) != 1 || stats[0].Algorithm != Radix {
+		t.Fatal("wide range not sorted as by Sort", stats)
+	}
+}
+
+func TestSortRangePanics(t *testing.T) {
+	wide := make([]int, 10)
+	for i := range wide {
+		wide[i] = i << 20
+	}
+	for _, test := range []struct {
+		name     string
+		x        []int
+		min, max int
+	}{
+		{"out of range", []int{1, 5, 2, 9}, 1, 5},
+		{"out of wide range", wide, 0, wide[len(wide)-2]}, // sorted as by Sort
+		{"max less than min", nil, 5, 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: did not panic", test.name)
+				}
+			}()
+			SortRange(test.x, test.min, test.max)
+		}()
+	}
+}
+
+func TestLowSpanSort(t *testing.T) {
+	for _, tc := range []struct {
+		n        int
+		min, max int64
+		sort     bool
+	}{
+		{testSize, -3, 500, true},
+		{testSize, 100, 599, true},
+		{testSize, math.MaxInt64 - 255, math.MaxInt64, true},
+		{testSize, 0, 256 + rangeSpanPerElem*testSize, false}, // too wide to count
+		{lowSpanMax, 0, lowSpanMax - 1, true},
+		{lowSpanMax, 0, lowSpanMax, false},
+	} {
+		x := make([]int64, tc.n)
+		for i := range x {
+			x[i] = tc.min + rand.Int63n(tc.max-tc.min+1)
+		}
+		x[0], x[1] = tc.min, tc.max // span all of the range
+		control := slices.Clone(x)
+		slices.Sort(control)
+		lo, span, ok := lowSpan(x)
+		if ok != tc.sort {
+			t.Fatalf("[%d, %d] of %d: expected %v, got %v", tc.min, tc.max, tc.n, tc.sort, ok)
+		}
+		if !ok {
+			continue
+		}
+		if lo != tc.min || span != int(tc.max-tc.min)+1 {
+			t.Fatalf("[%d, %d] of %d: wrong span from %d of %d", tc.min, tc.max, tc.n, lo, span)
+		}
+		passes := 0
+		err := lowSpanSort(x, lo, span, func(done int) error {
+			passes = done
+			return nil
+		})
+		if err != nil || !slices.Equal(control, x) || passes != 1 {
+			t.Fatalf("[%d, %d] of %d: low span sort failed", tc.min, tc.max, tc.n)
+		}
+	}
+}
+
+func TestSortLowSpanStats(t *testing.T) {
+	var stats Stats
+	test := NewSorterWith[int64](WithObserver(func(s Stats) { stats = s }))
+	x := make([]int64, 5000)
+	internal.FillSlice(x, func() int64 { return rand.Int63n(500) })
+	test.Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("low span sort failed")
+	}
+	if stats.Algorithm != Counting || stats.Passes != 1 || stats.SkippedPasses != 0 || stats.Allocs != 0 {
+		t.Fatal("low span sort not reported as counting sort", stats)
+	}
+	if test.(BufferedSorter[int64]).BufferBytes() != 0 {
+		t.Fatal("low span sort allocated a buffer")
+	}
+
+	var all []Stats
+	SetObserver(func(s Stats) { all = append(all, s) })
+	defer SetObserver(nil)
+	internal.FillSlice(x, func() int64 { return rand.Int63n(500) })
+	Sort(x)
+	internal.FillSlice(x, func() int64 { return rand.Int63n(500) })
+	SortBYOB(x, nil)
+	if !slices.IsSorted(x) || len(all) != 2 || all[0].Algorithm != Counting || all[1].Algorithm != Counting ||
+		all[0].Allocs != 0 {
+		t.Fatal("low span sorts not reported as counting sorts", all)
+	}
+}
+
+func TestSortLowSpan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("large sort")
+	}
+	// Large enough for wide digits, which must not skip finding the span
+	x := make([]int64, widestRadixMin)
+	internal.FillSlice(x, func() int64 { return rand.Int63n(200) })
+	control := slices.Clone(x)
+	slices.Sort(control)
+	var stats Stats
+	NewSorterWith[int64](WithObserver(func(s Stats) { stats = s })).Sort(x)
+	if !slices.Equal(control, x) {
+		t.Fatal("low span sort failed")
+	}
+	if stats.Algorithm != Counting || stats.Passes != 1 {
+		t.Errorf("low span sort took %d passes, expected 1", stats.Passes)
+	}
+}
@@ -0,0 +1,92 @@
+package zermelo
+
+import (
+	"context"
+	"slices"
+)
+
+// maxMergeRuns is the most sorted runs merged instead of radix sorted, taking at most two merge passes over x.
+const maxMergeRuns = 4
+
+// sortRuns sorts x, whose elements are size bits wide, if it is made of at most maxMergeRuns runs, each ascending or
+// strictly descending, and returns true if it did, observed by local as Merge. Descending runs are reversed in place,
+// then the runs are merged through a buffer of at least len(x) elements. This is checked before radix sort, so
+// buffer is only called for one, recording any allocation in ob, if there is more than one run to merge.
+// Random input is given up on within a few elements.
+// If floatBits is not zero, x holds the bits of floats that wide, which are ordered as floats, see flipFloat.
+func sortRuns[T Integer](ctx context.Context, local Observer, x []T, size, floatBits uint,
+	buffer func(n int, ob *observation) []T) bool {
+	var bounds [maxMergeRuns + 1]int
+	runs := findRuns(x, &bounds, floatBits)
+	if runs == 0 {
+		return false
+	}
+	ob := startObserving(ctx, local, Merge, len(x), size)
+	if runs > 1 {
+		mergeRuns(x, buffer(len(x), ob)[:len(x)], bounds[:runs+1], floatBits)
+	}
+	ob.finish(0, nil)
+	return true
+}
+
+// findRuns splits x into runs, reversing strictly descending runs so they ascend, and sets bounds to the start of
+// each run followed by len(x). It returns the number of runs, or 0 if there are more than maxMergeRuns.
+// Strictly descending runs have no equal elements, so reversing them keeps equal elements in order.
+func findRuns[T Integer](x []T, bounds *[maxMergeRuns + 1]int, floatBits uint) int {
+	runs := 0
+	for i := 0; i < len(x); runs++ {
+		if runs == maxMergeRuns {
+			return 0
+		}
+		bounds[runs] = i
+		j := i + 1
+		if j < len(x) && floatKey(x[j], floatBits) < floatKey(x[i], floatBits) {
+			for j++; j < len(x) && floatKey(x[j], floatBits) < floatKey(x[j-1], floatBits); j++ {
+			}
+			slices.Reverse(x[i:j])
+		} else {
+			for ; j < len(x) && floatKey(x[j], floatBits) >= floatKey(x[j-1], floatBits); j++ {
+			}
+		}
+		i = j
+	}
+	bounds[runs] = len(x)
+	return runs
+}
+
+// mergeRuns merges neighbouring pairs of the ascending runs of x, bounded by bounds, back and forth between x and
+// buffer until one run is left in x.
+func mergeRuns[T Integer](x, buffer []T, bounds []int, floatBits uint) {
+	from, to := x, buffer
+	for len(bounds) > 2 {
+		merged := 1
+		for i := 0; i+1 < len(bounds); i += 2 {
+			end := bounds[min(i+2, len(bounds)-1)]
+			mergeTwo(to[bounds[i]:end], from[bounds[i]:bounds[i+1]], from[bounds[i+1]:end], floatBits)
+			bounds[merged] = end
+			merged++
+		}
+		bounds = bounds[:merged]
+		from, to = to, from
+	}
+	if &from[0] != &x[0] {
+		copy(x, from)
+	}
+}
+
+// mergeTwo merges the ascending slices a and b into to, taking from a first on ties.
+func mergeTwo[T Integer](to, a, b []T, floatBits uint) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if floatKey(b[j], floatBits) < floatKey(a[i], floatBits) {
+			to[k] = b[j]
+			j++
+		} else {
+			to[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(to[k:], a[i:])
+	copy(to[k:], b[j:])
+}
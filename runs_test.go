@@ -0,0 +1,141 @@
+package zermelo
+
+import (
+	"context"
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortRuns(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		runs   []int // lengths, negative for descending
+		sorted bool
+	}{
+		{"ascending", []int{testSize}, true},
+		{"descending", []int{-testSize}, true},
+		{"two", []int{testSize / 2, testSize / 2}, true},
+		{"mixed", []int{testSize / 4, -testSize / 4, 7, -testSize / 2}, true},
+		{"odd", []int{100, 200, 300}, true},
+		{"many", []int{100, 100, 100, 100, 100}, false},
+	} {
+		var x []int32
+		for _, n := range tc.runs {
+			run := make([]int32, max(n, -n))
+			internal.FillSlice(run, internal.RandInteger[int32]())
+			slices.Sort(run)
+			if n < 0 {
+				slices.Reverse(run)
+			}
+			x = append(x, run...)
+		}
+		control := slices.Clone(x)
+		slices.Sort(control)
+		buffers := 0
+		sorted := sortRuns(context.Background(), nil, x, 32, 0, func(n int, _ *observation) []int32 {
+			buffers++
+			return make([]int32, n)
+		})
+		if sorted != tc.sorted {
+			t.Fatalf("%s: expected sorted %v", tc.name, tc.sorted)
+		}
+		if tc.sorted && !slices.Equal(control, x) {
+			t.Fatalf("%s: merged runs not sorted", tc.name)
+		}
+		if tc.sorted && (buffers == 1) != (len(tc.runs) > 1) {
+			t.Fatalf("%s: took %d buffers for %d runs", tc.name, buffers, len(tc.runs))
+		}
+	}
+
+	// Random input is given up on
+	x := make([]uint64, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint64]())
+	if sortRuns(context.Background(), nil, x, 64, 0, byob(make([]uint64, testSize))) {
+		t.Fatal("random input sorted as runs")
+	}
+}
+
+func TestSortDescending(t *testing.T) {
+	x := make([]uint64, testSize)
+	internal.FillSlice(x, internal.RandInteger[uint64]())
+	slices.Sort(x)
+	slices.Reverse(x)
+	var stats []Stats
+	test := NewSorterWith[uint64](WithObserver(func(s Stats) {
+		stats = append(stats, s)
+	}))
+	test.Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("descending input not sorted")
+	}
+
+	// More than one run needs a buffer
+	slices.Reverse(x[:testSize/2])
+	test.Sort(x)
+	if !slices.IsSorted(x) || len(stats) != 2 {
+		t.Fatal("runs not sorted", stats)
+	}
+	expected := []Stats{
+		{Algorithm: Merge, Len: testSize, Size: 64},
+		{Algorithm: Merge, Len: testSize, Size: 64, Allocs: 1, AllocBytes: 8 * testSize},
+	}
+	for i, s := range stats {
+		if s.Elapsed <= 0 {
+			t.Fatal("elapsed not measured", s)
+		}
+		if s.Elapsed = 0; s != expected[i] {
+			t.Fatalf("runs not merged, expected %+v, got %+v", expected[i], s)
+		}
+	}
+}
+
+// byob returns a buffer func for sortRuns that returns buffer.
+func byob[T Integer](buffer []T) func(int, *observation) []T {
+	return func(int, *observation) []T {
+		return buffer
+	}
+}
+
+func TestSortFloatRuns(t *testing.T) {
+	// Two runs in float order, neither of which is a run as integers
+	x := make([]float64, testSize)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	slices.Sort(x[:testSize/2])
+	slices.Sort(x[testSize/2:])
+	slices.Reverse(x[testSize/2:])
+	bits := make([]uint64, len(x))
+	for i, f := range x {
+		bits[i] = math.Float64bits(f)
+	}
+	slices.Sort(x)
+	if !sortRuns(context.Background(), nil, bits, 64, 64, byob(make([]uint64, len(bits)))) {
+		t.Fatal("float runs not found")
+	}
+	for i, f := range x {
+		if bits[i] != math.Float64bits(f) {
+			t.Fatal("merged float runs not sorted")
+		}
+	}
+}
+
+func TestSortDescendingParallel(t *testing.T) {
+	x := make([]uint32, 4*parallelMinChunk)
+	internal.FillSlice(x, internal.RandInteger[uint32]())
+	slices.Sort(x)
+	slices.Reverse(x)
+	var stats []Stats
+	NewSorterWith[uint32](WithParallelism(4), WithObserver(func(s Stats) {
+		stats = append(stats, s)
+	})).Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("descending input not sorted")
+	}
+	if len(stats) != 1 || stats[0].Algorithm != Merge || stats[0].Passes != 0 || stats[0].SkippedPasses != 0 {
+		t.Fatal("descending input was radix sorted", stats)
+	}
+}
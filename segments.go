@@ -0,0 +1,38 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+)
+
+// SortSegments sorts each segment x[offsets[i]:offsets[i+1]] of x independently, as for rows of a sparse matrix
+// stored in one flat slice. Offsets must be non-decreasing and within len(x), and elements of x outside of all
+// segments are left as they are. Segments shorter than the cutoff for T, see SetCutoff, are sorted with SmallSort,
+// and longer ones are radix sorted, all sharing one buffer the size of the longest segment, or counting sorted
+// without a buffer, as SortBYOB does.
+func SortSegments[T Integer](x []T, offsets []int) {
+	size, minval := internal.Detect[T]()
+	cutoff := cutoffs.Get(size)
+
+	longest := 0 // of the segments to radix sort
+	for i := 1; i < len(offsets); i++ {
+		if n := offsets[i] - offsets[i-1]; !useCountingSort(size, n) {
+			longest = max(longest, n)
+		}
+	}
+	var buf *[]T
+	if longest >= max(cutoff, 2) {
+		buf, _ = internal.GetBuffer[T](longest)
+		defer internal.PutBuffer(buf)
+	}
+
+	for i := 1; i < len(offsets); i++ {
+		segment := x[offsets[i-1]:offsets[i]]
+		if len(segment) < max(cutoff, 2) {
+			SmallSort(segment)
+		} else if useCountingSort(size, len(segment)) {
+			_ = countingSort(segment, size, minval, nil)
+		} else {
+			sortBYOB(segment, *buf, size, minval)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSortSegments(t *testing.T) {
+	testSortSegments[uint64](t, []int{0, 0, 1, 5, 5, 200, 200 + testSize, 200 + 2*testSize + 3})
+	testSortSegments[int32](t, []int{3, 10, 500, 600, 601})
+	testSortSegments[int8](t, []int{0, testSize})
+	testSortSegments[uint16](t, nil)
+	testSortSegments[int](t, []int{7})
+}
+
+func testSortSegments[I Integer](t *testing.T, offsets []int) {
+	x := make([]I, 2*testSize+210)
+	internal.FillSlice(x, internal.RandInteger[I]())
+	control := slices.Clone(x)
+	for i := 1; i < len(offsets); i++ {
+		slices.Sort(control[offsets[i-1]:offsets[i]])
+	}
+	SortSegments(x, offsets)
+	if !slices.Equal(x, control) {
+		t.Fatalf("%T: segments %v not sorted", I(0), offsets)
+	}
+}
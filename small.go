@@ -0,0 +1,33 @@
+package zermelo
+
+import (
+	"slices"
+)
+
+// insertionSortMax is the longest slice SmallSort sorts with insertion sort, past the sorting networks.
+const insertionSortMax = 48
+
+// SmallSort sorts short integer slices, as the sorters in this package do below their cutoff.
+// Slices of up to 16 elements are sorted by branchless sorting networks, slightly longer ones by insertion sort,
+// and anything longer by slices.Sort.
+func SmallSort[T Integer](x []T) {
+	switch {
+	case sortNetwork(x):
+	case len(x) <= insertionSortMax:
+		insertionSort(x)
+	default:
+		slices.Sort(x)
+	}
+}
+
+// insertionSort sorts x by moving each element down past the larger elements before it.
+func insertionSort[T Integer](x []T) {
+	for i := 1; i < len(x); i++ {
+		elem := x[i]
+		j := i
+		for ; j > 0 && x[j-1] > elem; j-- {
+			x[j] = x[j-1]
+		}
+		x[j] = elem
+	}
+}
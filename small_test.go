@@ -0,0 +1,91 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// TestSortNetwork checks every network sorts all inputs of zeros and ones, which by the zero-one principle means
+// it sorts any input.
+func TestSortNetwork(t *testing.T) {
+	for n := 0; n <= 16; n++ {
+		x := make([]uint8, n)
+		for bits := 0; bits < 1<<n; bits++ {
+			for i := range x {
+				x[i] = uint8(bits >> i & 1)
+			}
+			if !sortNetwork(x) || !slices.IsSorted(x) {
+				t.Fatalf("network %d failed on %b", n, bits)
+			}
+		}
+	}
+	if sortNetwork(make([]int, 17)) {
+		t.Fatal("network sorted 17 elements")
+	}
+}
+
+func TestSmallSort(t *testing.T) {
+	testSmallSort[int64](t, internal.RandInteger[int64]())
+	testSmallSort[uint32](t, internal.RandInteger[uint32]())
+	testSmallSort[int8](t, internal.RandInteger[int8]())
+}
+
+func testSmallSort[T Integer](t *testing.T, gen func() T) {
+	for n := 0; n <= 2*insertionSortMax; n++ {
+		x := make([]T, n)
+		internal.FillSlice(x, gen)
+		control := slices.Clone(x)
+		slices.Sort(control)
+		SmallSort(x)
+		if !slices.Equal(control, x) {
+			t.Fatalf("%T: small sort of %d failed", T(0), n)
+		}
+	}
+}
+
+func TestWithSmallSorter(t *testing.T) {
+	calls := 0
+	small := func(x []uint32) {
+		calls++
+		insertionSort(x)
+	}
+	test := NewSorterWith[uint32](WithSmallSorter(small), WithCutoff(20))
+	for n := 0; n < 40; n++ {
+		sortAndCheck(t, test, n)
+	}
+	if calls != 20 {
+		t.Fatal("wrong small sorter calls", calls)
+	}
+
+	// Small sorters of other types are ignored
+	other := NewSorterWith[int](WithSmallSorter(func([]uint32) { t.Fatal("wrong type small sorter") }))
+	x := []int{3, 1, 2}
+	other.Sort(x)
+	if !slices.IsSorted(x) {
+		t.Fatal("not sorted", x)
+	}
+}
+
+func TestWithSmallSorterBuckets(t *testing.T) {
+	for _, parallelism := range []int{1, 3} {
+		var calls atomic.Int64
+		small := func(x []uint32) {
+			calls.Add(1)
+			slices.Sort(x)
+		}
+		test := NewSorterWith[uint32](WithSmallSorter(small), WithCutoff(1<<14), WithParallelism(parallelism))
+		x := make([]uint32, hybridMin/4) // partitioned into 256 buckets of about 1<<13
+		internal.FillSlice(x, internal.RandInteger[uint32]())
+		control := slices.Clone(x)
+		slices.Sort(control)
+		test.Sort(x)
+		if !slices.Equal(control, x) {
+			t.Fatal("hybrid sort with small sorter failed")
+		}
+		if calls.Load() != 256 {
+			t.Fatal("wrong small sorter calls for buckets", calls.Load())
+		}
+	}
+}
@@ -2,16 +2,43 @@ package zermelo
 
 import (
 	"cmp"
+	"context"
 	"github.com/shawnsmithdev/zermelo/v2/internal"
 	"slices"
 )
 
 // Sorter describes types that can sort slices.
 type Sorter[T cmp.Ordered] interface {
-	// Sort sorts slices in ascending order.
+	// Sort sorts slices in ascending order, unless the Sorter was created with WithDescending.
 	Sort(x []T)
 }
 
+// BufferedSorter is a Sorter that keeps a buffer between sorts, allowing control over that buffer's memory.
+// Sorters returned by NewSorter, NewSorterWith and the floats package implement BufferedSorter.
+type BufferedSorter[T cmp.Ordered] interface {
+	Sorter[T]
+	// Reserve grows the buffer, if needed, so slices of up to n elements can be sorted without allocating.
+	// The buffer is not grown beyond the Sorter's maximum retained size, if it has one.
+	Reserve(n int)
+	// Shrink reduces the buffer to what the longest slice sorted or reserved since the last Shrink or Release needed.
+	// Calling Shrink periodically lets the buffer follow the recent working set instead of the peak.
+	Shrink()
+	// Release discards the buffer. It is allocated again if needed by a later sort.
+	Release()
+	// BufferBytes returns the size of the buffer in bytes.
+	BufferBytes() int
+}
+
+// ContextSorter is a Sorter that can stop sorting early when a context is done.
+// Sorters returned by NewSorter, NewSorterWith, NewConcurrentSorter and the floats package implement ContextSorter.
+type ContextSorter[T cmp.Ordered] interface {
+	Sorter[T]
+	// SortContext sorts like Sort, but stops early and returns ctx.Err() if ctx is done.
+	// Cancellation is checked between radix sort passes. If sorting is stopped, x is left holding all of its
+	// original elements in an unspecified order.
+	SortContext(ctx context.Context, x []T) error
+}
+
 // cutoffSorter is a Sorter with adjustable comparison sort cutoff, for testing.
 type cutoffSorter[T Integer] interface {
 	Sorter[T]
@@ -20,20 +47,169 @@ type cutoffSorter[T Integer] interface {
 
 type sorter[I Integer] struct {
 	buf            []I
-	compSortCutoff int
+	compSortCutoff int // internal.DefaultCutoff to use the process-wide cutoff
+	growth         float64
+	maxRetained    int
+	peak           int // longest buffer needed since last Shrink or Release
+	descending     bool
+	parallelism    int
+	floatBits      bool // elements are the bits of floats, see internal.Options
+	small          SmallSorter[I]
+	progress       func(done, total int)
+	observer       Observer
 	minval         I
 	size           uint
 }
 
 func (s *sorter[I]) Sort(x []I) {
-	if len(x) < s.compSortCutoff {
-		slices.Sort(x)
-		return
+	_ = s.sort(context.Background(), x, nil)
+}
+
+func (s *sorter[I]) SortContext(ctx context.Context, x []I) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.sort(ctx, x, contextPass(ctx))
+}
+
+// sort sorts x, calling cancelled after each pass if it is not nil.
+func (s *sorter[I]) sort(ctx context.Context, x []I, cancelled passFunc) error {
+	cutoff := s.compSortCutoff
+	if cutoff == internal.DefaultCutoff {
+		cutoff = cutoffs.Get(s.size)
+	}
+	if len(x) < cutoff {
+		ob := startObserving(ctx, s.observer, Comparison, len(x), s.size)
+		s.small(x)
+		ob.finish(0, nil)
+		if s.progress != nil {
+			s.progress(1, 1)
+		}
+	} else if useCountingSort(s.size, len(x)) {
+		ob := startObserving(ctx, s.observer, Counting, len(x), s.size)
+		err := countingSort(x, s.size, s.minval, chainPass(ob.passFunc(), cancelled))
+		ob.finish(1, err)
+		if err != nil {
+			return err
+		}
+		if s.progress != nil {
+			s.progress(1, 1)
+		}
+	} else if s.sortRuns(ctx, x) {
+		if s.progress != nil {
+			s.progress(1, 1)
+		}
+	} else if ok, err := s.sortLowSpan(ctx, x, cancelled); ok {
+		if err != nil {
+			return err
+		}
+		if s.progress != nil {
+			s.progress(1, 1)
+		}
+	} else if err := s.radixSort(ctx, x, shortSort[I]{cutoff: cutoff, sort: s.small}, cancelled); err != nil {
+		return err
+	}
+	if s.descending {
+		slices.Reverse(x)
+	}
+	return nil
+}
+
+// sortRuns is sortRuns for the Sorter, taking the buffer to merge runs only if needed.
+func (s *sorter[I]) sortRuns(ctx context.Context, x []I) bool {
+	var floatBits uint
+	if s.floatBits {
+		floatBits = s.size
+	}
+	return sortRuns(ctx, s.observer, x, s.size, floatBits, s.buffer)
+}
+
+// sortLowSpan is sortLowSpan for the Sorter, and returns false for the bits of floats, which are not ordered as
+// integers.
+func (s *sorter[I]) sortLowSpan(ctx context.Context, x []I, cancelled passFunc) (bool, error) {
+	if s.floatBits {
+		return false, nil
+	}
+	return sortLowSpan(ctx, s.observer, x, s.size, cancelled)
+}
+
+// radixSort radix sorts x, reporting progress and calling cancelled after each pass as needed.
+// Partitions too short for radix sort are sorted with short.
+func (s *sorter[I]) radixSort(ctx context.Context, x []I, short shortSort[I], cancelled passFunc) error {
+	ob := startObserving(ctx, s.observer, Radix, len(x), s.size)
+	passes := radixPasses(s.size, len(x))
+	done := 0
+	var progress passFunc
+	if s.progress != nil {
+		progress = func(d int) error {
+			done = d
+			s.progress(done, passes)
+			return nil
+		}
+	}
+	pass := chainPass(progress, ob.passFunc(), cancelled)
+
+	var err error
+	if s.floatBits {
+		err = sortFloatBits(x, s.buffer(len(x), ob), s.size, s.parallelism, pass)
+	} else if s.parallelism > 1 {
+		err = sortBYOBParallel(x, s.buffer(len(x), ob), s.size, s.minval, short, s.parallelism, pass)
+	} else {
+		err = radixSort(x, s.buffer(len(x), ob), s.size, s.minval, short, pass)
+	}
+	ob.finish(passes, err)
+	if err == nil && s.progress != nil && done < passes { // sorted early
+		s.progress(passes, passes)
+	}
+	return err
+}
+
+// buffer returns a buffer of at least n elements, growing the retained buffer if allowed.
+// Allocations are recorded in ob.
+func (s *sorter[I]) buffer(n int, ob *observation) []I {
+	s.peak = max(s.peak, n)
+	if len(s.buf) >= n {
+		return s.buf
+	}
+	size := allocSize(len(s.buf), n, s.growth)
+	if s.maxRetained > 0 {
+		if n > s.maxRetained {
+			ob.alloc(n * int(s.size/8))
+			return make([]I, n) // too large to keep
+		}
+		size = min(size, s.maxRetained)
 	}
-	if len(s.buf) < len(x) {
-		s.buf = make([]I, allocSize(len(s.buf), len(x)))
+	ob.alloc(size * int(s.size/8))
+	s.buf = make([]I, size)
+	return s.buf
+}
+
+func (s *sorter[I]) Reserve(n int) {
+	if s.maxRetained > 0 {
+		n = min(n, s.maxRetained)
+	}
+	s.peak = max(s.peak, n)
+	if len(s.buf) < n {
+		s.buf = make([]I, n)
+	}
+}
+
+func (s *sorter[I]) Shrink() {
+	if s.peak == 0 {
+		s.buf = nil
+	} else if s.peak < len(s.buf) {
+		s.buf = make([]I, s.peak)
 	}
-	sortBYOB(x, s.buf, s.size, s.minval)
+	s.peak = 0
+}
+
+func (s *sorter[I]) Release() {
+	s.buf = nil
+	s.peak = 0
+}
+
+func (s *sorter[I]) BufferBytes() int {
+	return len(s.buf) * int(s.size/8)
 }
 
 func (s *sorter[I]) withCutoff(cutoff int) cutoffSorter[I] {
@@ -44,30 +220,64 @@ func (s *sorter[I]) withCutoff(cutoff int) cutoffSorter[I] {
 // NewSorter creates a new Sorter that will use radix sort on large slices and reuses buffers.
 // The first sort creates a buffer the same size as the slice being sorted and keeps it for future use.
 // Later sorts may grow this buffer as needed. The Sorter returned is not thread safe.
+// The Sorter returned is a BufferedSorter, allowing the buffer to be reserved ahead of time or released,
+// and a ContextSorter, allowing sorts to be cancelled.
 // Using this sorter can be much faster than repeat calls to Sort.
 func NewSorter[I Integer]() Sorter[I] {
 	return newSorter[I]()
 }
 
-func newSorter[I Integer]() cutoffSorter[I] {
-	size, minval := internal.Detect[I]()
-	cutoff := compSortCutoff
-	if size == 64 {
-		cutoff = compSortCutoff64
+// NewSorterWith creates a new Sorter like NewSorter, configured by the given options.
+func NewSorterWith[I Integer](opts ...Option) Sorter[I] {
+	return newSorter[I](opts...)
+}
+
+func newSorter[I Integer](opts ...Option) cutoffSorter[I] {
+	o := internal.ApplyOptions(opts)
+	observer, _ := o.Observer.(Observer)
+	small, _ := o.SmallSorter.(SmallSorter[I])
+	if small == nil {
+		small = SmallSort[I]
 	}
-	return &sorter[I]{
-		compSortCutoff: cutoff,
+	size, minval := internal.Detect[I]()
+	result := &sorter[I]{
+		compSortCutoff: o.Cutoff,
+		growth:         o.Growth,
+		maxRetained:    o.MaxRetained,
+		descending:     o.Descending,
+		parallelism:    o.Parallelism,
+		floatBits:      o.FloatBits,
+		small:          small,
+		progress:       o.Progress,
+		observer:       observer,
 		minval:         minval,
 		size:           size,
 	}
+	if o.Capacity > 0 {
+		capacity := o.Capacity
+		if o.MaxRetained > 0 {
+			capacity = min(capacity, o.MaxRetained)
+		}
+		result.buf = make([]I, capacity)
+	}
+	return result
 }
 
 // Given an existing buffer capacity and a requested one, finds a new buffer size.
 // For the first alloc this will equal requested size, then after at it leaves
-// a 25% buffer for future growth.
-func allocSize(bufCap, reqLen int) int {
+// room for future growth, 25% by default.
+func allocSize(bufCap, reqLen int, growth float64) int {
 	if bufCap == 0 {
 		return reqLen
 	}
-	return 5 * reqLen / 4
+	return max(reqLen, int(float64(reqLen)*growth))
+}
+
+// NewConcurrentSorter creates a new Sorter like NewSorterWith that is safe for concurrent use.
+// Each call to Sort borrows a Sorter, and its buffer, from a pool kept per P.
+// Pooled Sorters are freed by the garbage collector when unused.
+func NewConcurrentSorter[I Integer](opts ...Option) Sorter[I] {
+	return internal.NewConcurrentSorter[I](func() internal.Sorter[I] {
+		return newSorter[I](opts...)
+	})
 }
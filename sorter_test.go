@@ -3,6 +3,7 @@ package zermelo
 import (
 	"github.com/shawnsmithdev/zermelo/v2/internal"
 	"slices"
+	"sync"
 	"testing"
 )
 
@@ -33,13 +34,23 @@ func TestSorter(t *testing.T) {
 	testSorter[uint](t, internal.RandInteger[uint](), true)
 }
 
+func TestSorterWithCutoff(t *testing.T) {
+	testSorterWith[int32](t, internal.RandInteger[int32](), NewSorterWith[int32](WithCutoff(0)))
+	testSorterWith[int32](t, internal.RandInteger[int32](), NewSorterWith[int32](WithCutoff(testSize/2)))
+	testSorterWith[uint64](t, internal.RandInteger[uint64](), NewSorterWith[uint64](WithCutoff(0)))
+	testSorterWith[uint64](t, internal.RandInteger[uint64](), NewSorterWith[uint64](WithCutoff(testSize/2)))
+	testSorterWith[uint64](t, internal.RandInteger[uint64](), NewSorterWith[uint64]())
+}
+
 func testSorter[I Integer](t *testing.T, gen func() I, cutoff bool) {
-	var test Sorter[I]
 	if cutoff {
-		test = NewSorter[I]()
+		testSorterWith(t, gen, NewSorter[I]())
 	} else {
-		test = newSorter[I]().withCutoff(0)
+		testSorterWith(t, gen, newSorter[I]().withCutoff(0))
 	}
+}
+
+func testSorterWith[I Integer](t *testing.T, gen func() I, test Sorter[I]) {
 	toTest := make([]I, testSize)
 	for i := 0; i < testSize; i++ {
 		internal.FillSlice(toTest[:i], gen)
@@ -48,11 +59,74 @@ func testSorter[I Integer](t *testing.T, gen func() I, cutoff bool) {
 		copied := slices.Clone(toTest[:i])
 		test.Sort(copied)
 		if !slices.Equal(copied, control) {
-			t.Fatal("cutoff=", cutoff, ", copied != control", copied, control)
+			t.Fatal("copied != control", copied, control)
 		}
 		test.Sort(toTest[:i])
 		if !slices.Equal(toTest[:i], control) {
-			t.Fatal("cutoff=", cutoff, "toTest ! control", toTest, control)
+			t.Fatal("toTest ! control", toTest, control)
 		}
 	}
 }
+
+func TestBufferedSorter(t *testing.T) {
+	test := NewSorterWith[uint32](WithCutoff(0)).(BufferedSorter[uint32])
+	if test.BufferBytes() != 0 {
+		t.Fatal("buffer allocated before use", test.BufferBytes())
+	}
+	test.Reserve(1000)
+	if test.BufferBytes() != 4000 {
+		t.Fatal("wrong reserved size", test.BufferBytes())
+	}
+	sortAndCheck[uint32](t, test, 100)
+	if test.BufferBytes() != 4000 {
+		t.Fatal("buffer grew after reserve", test.BufferBytes())
+	}
+	test.Shrink() // reserved since last shrink
+	if test.BufferBytes() != 4000 {
+		t.Fatal("shrink dropped reserved buffer", test.BufferBytes())
+	}
+	sortAndCheck[uint32](t, test, 100)
+	test.Shrink()
+	if test.BufferBytes() != 400 {
+		t.Fatal("shrink did not reduce buffer", test.BufferBytes())
+	}
+	test.Shrink()
+	if test.BufferBytes() != 0 {
+		t.Fatal("shrink without sorts kept buffer", test.BufferBytes())
+	}
+	sortAndCheck[uint32](t, test, 50)
+	test.Release()
+	if test.BufferBytes() != 0 {
+		t.Fatal("release kept buffer", test.BufferBytes())
+	}
+	sortAndCheck[uint32](t, test, 50)
+
+	capped := NewSorterWith[uint64](WithMaxRetained(10)).(BufferedSorter[uint64])
+	capped.Reserve(100)
+	if capped.BufferBytes() != 80 {
+		t.Fatal("reserve exceeded max retained", capped.BufferBytes())
+	}
+}
+
+func TestConcurrentSorter(t *testing.T) {
+	test := NewConcurrentSorter[uint64]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := internal.RandInteger[uint64]()
+			for n := 0; n < testSize; n += 7 {
+				x := make([]uint64, n)
+				internal.FillSlice(x, rng)
+				test.Sort(x)
+				if !slices.IsSorted(x) {
+					t.Error("concurrent sort failed for length", n)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	testSorterWith[int16](t, internal.RandInteger[int16](), NewConcurrentSorter[int16](WithCutoff(0)))
+}
@@ -0,0 +1,270 @@
+// Code generated by go run ./internal/gen. DO NOT EDIT.
+
+package zermelo
+
+import (
+	"math"
+)
+
+// specializedHistograms is histograms without generic code, for the built-in integer types.
+// It returns ok false if there is no specialization for T.
+func specializedHistograms[T Integer](x []T, counts [][256]int) (sorted, ok bool) {
+	switch x := any(x).(type) {
+	case []int:
+		return histogramsInt(x, counts), true
+	case []int64:
+		return histogramsInt64(x, counts), true
+	case []int32:
+		return histogramsInt32(x, counts), true
+	case []uint64:
+		if haveKernels {
+			return kernelHistograms64(x, counts), true
+		}
+		return histogramsUint64(x, counts), true
+	case []uint32:
+		if haveKernels {
+			return kernelHistograms32(x, counts), true
+		}
+		return histogramsUint32(x, counts), true
+	}
+	return false, false
+}
+
+// specializedScatter is the scatter loop of lsdRadixSort without generic code, for the built-in integer types.
+// It returns false if there is no specialization for T.
+func specializedScatter[T Integer](from, to []T, offset *[256]int, shift uint) bool {
+	switch from := any(from).(type) {
+	case []int:
+		scatterInt(from, any(to).([]int), offset, shift)
+		return true
+	case []int64:
+		scatterInt64(from, any(to).([]int64), offset, shift)
+		return true
+	case []int32:
+		scatterInt32(from, any(to).([]int32), offset, shift)
+		return true
+	case []uint64:
+		if haveKernels {
+			scatter64(from, any(to).([]uint64)[:len(from)], offset, shift)
+			return true
+		}
+		scatterUint64(from, any(to).([]uint64), offset, shift)
+		return true
+	case []uint32:
+		if haveKernels {
+			scatter32(from, any(to).([]uint32)[:len(from)], offset, shift)
+			return true
+		}
+		scatterUint32(from, any(to).([]uint32), offset, shift)
+		return true
+	}
+	return false
+}
+
+// histogramsInt is histograms for []int.
+func histogramsInt(x []int, counts [][256]int) bool {
+	var (
+		prev   = int(math.MinInt)
+		sorted = true
+	)
+	switch len(counts) {
+	case 4:
+		c0 := &counts[0]
+		c1 := &counts[1]
+		c2 := &counts[2]
+		c3 := &counts[3]
+		for _, elem := range x {
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	default:
+		c0 := &counts[0]
+		c1 := &counts[1]
+		c2 := &counts[2]
+		c3 := &counts[3]
+		c4 := &counts[4]
+		c5 := &counts[5]
+		c6 := &counts[6]
+		c7 := &counts[7]
+		for _, elem := range x {
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			c4[uint8(u>>32)]++
+			c5[uint8(u>>40)]++
+			c6[uint8(u>>48)]++
+			c7[uint8(u>>56)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	}
+	return sorted
+}
+
+// scatterInt is the scatter loop of lsdRadixSort for []int.
+func scatterInt(from, to []int, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
+
+// histogramsInt64 is histograms for []int64.
+func histogramsInt64(x []int64, counts [][256]int) bool {
+	var (
+		prev   = int64(math.MinInt64)
+		sorted = true
+	)
+	c0 := &counts[0]
+	c1 := &counts[1]
+	c2 := &counts[2]
+	c3 := &counts[3]
+	c4 := &counts[4]
+	c5 := &counts[5]
+	c6 := &counts[6]
+	c7 := &counts[7]
+	for _, elem := range x {
+		u := uint64(elem)
+		c0[uint8(u)]++
+		c1[uint8(u>>8)]++
+		c2[uint8(u>>16)]++
+		c3[uint8(u>>24)]++
+		c4[uint8(u>>32)]++
+		c5[uint8(u>>40)]++
+		c6[uint8(u>>48)]++
+		c7[uint8(u>>56)]++
+		if sorted {
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+	return sorted
+}
+
+// scatterInt64 is the scatter loop of lsdRadixSort for []int64.
+func scatterInt64(from, to []int64, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
+
+// histogramsInt32 is histograms for []int32.
+func histogramsInt32(x []int32, counts [][256]int) bool {
+	var (
+		prev   = int32(math.MinInt32)
+		sorted = true
+	)
+	c0 := &counts[0]
+	c1 := &counts[1]
+	c2 := &counts[2]
+	c3 := &counts[3]
+	for _, elem := range x {
+		u := uint64(elem)
+		c0[uint8(u)]++
+		c1[uint8(u>>8)]++
+		c2[uint8(u>>16)]++
+		c3[uint8(u>>24)]++
+		if sorted {
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+	return sorted
+}
+
+// scatterInt32 is the scatter loop of lsdRadixSort for []int32.
+func scatterInt32(from, to []int32, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
+
+// histogramsUint64 is histograms for []uint64.
+func histogramsUint64(x []uint64, counts [][256]int) bool {
+	var (
+		prev   = uint64(0)
+		sorted = true
+	)
+	c0 := &counts[0]
+	c1 := &counts[1]
+	c2 := &counts[2]
+	c3 := &counts[3]
+	c4 := &counts[4]
+	c5 := &counts[5]
+	c6 := &counts[6]
+	c7 := &counts[7]
+	for _, elem := range x {
+		u := uint64(elem)
+		c0[uint8(u)]++
+		c1[uint8(u>>8)]++
+		c2[uint8(u>>16)]++
+		c3[uint8(u>>24)]++
+		c4[uint8(u>>32)]++
+		c5[uint8(u>>40)]++
+		c6[uint8(u>>48)]++
+		c7[uint8(u>>56)]++
+		if sorted {
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+	return sorted
+}
+
+// scatterUint64 is the scatter loop of lsdRadixSort for []uint64.
+func scatterUint64(from, to []uint64, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
+
+// histogramsUint32 is histograms for []uint32.
+func histogramsUint32(x []uint32, counts [][256]int) bool {
+	var (
+		prev   = uint32(0)
+		sorted = true
+	)
+	c0 := &counts[0]
+	c1 := &counts[1]
+	c2 := &counts[2]
+	c3 := &counts[3]
+	for _, elem := range x {
+		u := uint64(elem)
+		c0[uint8(u)]++
+		c1[uint8(u>>8)]++
+		c2[uint8(u>>16)]++
+		c3[uint8(u>>24)]++
+		if sorted {
+			sorted = elem >= prev
+			prev = elem
+		}
+	}
+	return sorted
+}
+
+// scatterUint32 is the scatter loop of lsdRadixSort for []uint32.
+func scatterUint32(from, to []uint32, offset *[256]int, shift uint) {
+	for _, elem := range from {
+		key := uint8(elem >> shift)
+		to[offset[key]] = elem
+		offset[key]++
+	}
+}
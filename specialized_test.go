@@ -0,0 +1,65 @@
+package zermelo
+
+import (
+	"github.com/shawnsmithdev/zermelo/v2/internal"
+	"slices"
+	"testing"
+)
+
+func TestSpecialized(t *testing.T) {
+	testSpecialized[int](t, internal.RandInteger[int](), true)
+	testSpecialized[int64](t, internal.RandInteger[int64](), true)
+	testSpecialized[int32](t, internal.RandInteger[int32](), true)
+	testSpecialized[uint64](t, internal.RandInteger[uint64](), true)
+	testSpecialized[uint32](t, internal.RandInteger[uint32](), true)
+	testSpecialized[int16](t, internal.RandInteger[int16](), false)
+	type id uint64
+	testSpecialized[id](t, func() id { return id(internal.RandInteger[uint64]()()) }, false)
+}
+
+func testSpecialized[T Integer](t *testing.T, gen func() T, specialized bool) {
+	size, _ := internal.Detect[T]()
+	for _, n := range []int{0, 1, 1000} {
+		x := make([]T, n)
+		internal.FillSlice(x, gen)
+		for _, sorted := range []bool{false, true} {
+			if sorted {
+				slices.Sort(x)
+			}
+			expected := make([][256]int, size/8)
+			for _, elem := range x {
+				for p := range expected {
+					expected[p][uint8(uint64(elem)>>(8*p))]++
+				}
+			}
+			got := make([][256]int, size/8)
+			gotSorted, ok := specializedHistograms(x, got)
+			if ok != specialized {
+				t.Fatalf("%T: specialized %v, expected %v", T(0), ok, specialized)
+			}
+			if ok && (gotSorted != slices.IsSorted(x) || !slices.Equal(expected, got)) {
+				t.Fatalf("%T: wrong specialized counts for %d elements", T(0), n)
+			}
+		}
+
+		var offset [256]int
+		for _, elem := range x {
+			offset[uint8(elem)]++
+		}
+		bucketOffsets(&offset, false)
+		expectedOffset := offset
+		expected := make([]T, n)
+		for _, elem := range x {
+			key := uint8(elem)
+			expected[expectedOffset[key]] = elem
+			expectedOffset[key]++
+		}
+		to := make([]T, n)
+		if specializedScatter(x, to, &offset, 0) != specialized {
+			t.Fatalf("%T: specialized scatter, expected %v", T(0), specialized)
+		}
+		if specialized && (!slices.Equal(expected, to) || offset != expectedOffset) {
+			t.Fatalf("%T: wrong specialized scatter of %d elements", T(0), n)
+		}
+	}
+}
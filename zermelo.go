@@ -2,102 +2,352 @@
 package zermelo // import "github.com/shawnsmithdev/zermelo/v2"
 
 import (
+	"context"
 	"github.com/shawnsmithdev/zermelo/v2/internal"
-	"slices"
 )
 
+//go:generate go run ./internal/gen
+
 const (
 	radix            uint = 8
+	maxPasses             = 64 / radix
 	compSortCutoff64      = 256
 	compSortCutoff        = 128
 )
 
-// Sort sorts integer slices. If the slice is large enough, radix sort is used by allocating a new buffer.
+// Sort sorts integer slices. If the slice is large enough, radix sort is used with a buffer borrowed from a pool,
+// see SetBufferPooling. Sort is safe to call from multiple goroutines on different slices.
+// Slices shorter than the cutoff for T, see SetCutoff, are sorted with SmallSort.
 func Sort[T Integer](x []T) {
+	_ = sortPooled(context.Background(), x, nil)
+}
+
+// SortContext sorts integer slices like Sort, but stops early and returns ctx.Err() if ctx is done.
+// Cancellation is checked between radix sort passes. If sorting is stopped, x is left holding all of its original
+// elements in an unspecified order.
+func SortContext[T Integer](ctx context.Context, x []T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sortPooled(ctx, x, contextPass(ctx))
+}
+
+// sortPooled sorts x using a pooled buffer if needed, calling cancelled after each pass if it is not nil.
+func sortPooled[T Integer](ctx context.Context, x []T, cancelled passFunc) error {
 	if len(x) < 2 {
-		return
+		return nil
 	}
 	size, minval := internal.Detect[T]()
-	if len(x) < compSortCutoff || (size == 64 && len(x) < compSortCutoff64) {
-		slices.Sort(x)
-	} else {
-		sortBYOB(x, make([]T, len(x)), size, minval)
+	if len(x) < cutoffs.Get(size) {
+		ob := startObserving(ctx, nil, Comparison, len(x), size)
+		SmallSort(x)
+		ob.finish(0, nil)
+		return nil
+	}
+	if useCountingSort(size, len(x)) {
+		ob := startObserving(ctx, nil, Counting, len(x), size)
+		err := countingSort(x, size, minval, chainPass(ob.passFunc(), cancelled))
+		ob.finish(1, err)
+		return err
 	}
+	buf := pooledBuffer[T]{size: size}
+	if sortRuns(ctx, nil, x, size, 0, buf.get) {
+		buf.release()
+		return nil
+	}
+	if ok, err := sortLowSpan(ctx, nil, x, size, cancelled); ok {
+		return err
+	}
+	ob := startObserving(ctx, nil, Radix, len(x), size)
+	err := radixSort(x, buf.get(len(x), ob), size, minval, shortSort[T]{}, chainPass(ob.passFunc(), cancelled))
+	buf.release()
+	ob.finish(radixPasses(size, len(x)), err)
+	return err
 }
 
 // SortBYOB sorts integer slices with radix sort using the provided buffer.
-// len(buffer) must be greater or equal to len(x).
+// len(buffer) must be greater or equal to len(x). Slices of 8 bit elements, and large slices of 16 bit elements,
+// are counting sorted instead, without using the buffer, as are slices of values within a narrow span. Slices made
+// of a few sorted runs are merged.
 func SortBYOB[T Integer](x, buffer []T) {
 	if len(x) >= 2 {
 		size, minval := internal.Detect[T]()
-		sortBYOB(x, buffer, size, minval)
+		if useCountingSort(size, len(x)) {
+			ob := startObserving(context.Background(), nil, Counting, len(x), size)
+			_ = countingSort(x, size, minval, ob.passFunc())
+			ob.finish(1, nil)
+			return
+		}
+		if sortRuns(context.Background(), nil, x, size, 0, func(int, *observation) []T { return buffer }) {
+			return
+		}
+		if ok, _ := sortLowSpan(context.Background(), nil, x, size, nil); ok {
+			return
+		}
+		ob := startObserving(context.Background(), nil, Radix, len(x), size)
+		_ = radixSort(x, buffer, size, minval, shortSort[T]{}, ob.passFunc())
+		ob.finish(radixPasses(size, len(x)), nil)
 	}
 }
 
-func sortBYOB[T Integer](x, buffer []T, size uint, minval T) {
-	from := x
-	to := buffer[:len(x)]
+// passFunc is called by radix sorts after each completed pass, with the number of passes done so far.
+// Returning an error stops the sort, leaving x a permutation of its original elements.
+type passFunc func(done int) error
 
-	var keyOffset uint
-	for keyOffset = 0; keyOffset < size; keyOffset += radix {
-		var (
-			offset [256]int // Keep track of where room is made for byte groups in the buffer
-			prev   = minval
-			key    uint8
-			sorted = true
-		)
-
-		for _, elem := range from {
-			// For each elem to sort, fetch the byte at current radix
-			key = uint8(elem >> keyOffset)
-			// inc count of bytes of this type
-			offset[key]++
-			if sorted { // Detect sorted
-				sorted = elem >= prev
-				prev = elem
+// contextPass returns a passFunc that stops sorting when ctx is done.
+func contextPass(ctx context.Context) passFunc {
+	return func(int) error {
+		return ctx.Err()
+	}
+}
+
+// chainPass returns a passFunc calling each of passes that is not nil in order, until one returns an error.
+// It returns nil if all of passes are nil.
+func chainPass(passes ...passFunc) passFunc {
+	var chain []passFunc
+	for _, pass := range passes {
+		if pass != nil {
+			chain = append(chain, pass)
+		}
+	}
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+	return func(done int) error {
+		for _, pass := range chain {
+			if err := pass(done); err != nil {
+				return err
 			}
 		}
+		return nil
+	}
+}
 
-		if sorted { // Short-circuit sorted
-			break
-		}
+func sortBYOB[T Integer](x, buffer []T, size uint, minval T) {
+	var bounds [maxMergeRuns + 1]int
+	if runs := findRuns(x, &bounds, 0); runs != 0 {
+		mergeRuns(x, buffer[:len(x)], bounds[:runs+1], 0)
+		return
+	}
+	if lo, span, ok := lowSpan(x); ok {
+		_ = lowSpanSort(x, lo, span, nil)
+		return
+	}
+	_ = radixSort(x, buffer, size, minval, shortSort[T]{}, nil)
+}
+
+// sortLowSpan counting sorts x, observed by local as Counting, if its values are within a narrow span, see lowSpan,
+// and returns true if it did. This is checked before a radix sort takes a buffer.
+func sortLowSpan[T Integer](ctx context.Context, local Observer, x []T, size uint, cancelled passFunc) (bool, error) {
+	lo, span, ok := lowSpan(x)
+	if !ok {
+		return false, nil
+	}
+	ob := startObserving(ctx, local, Counting, len(x), size)
+	err := lowSpanSort(x, lo, span, chainPass(ob.passFunc(), cancelled))
+	ob.finish(1, err)
+	return true, err
+}
+
+// radixSort is sortBYOB, calling pass after each pass if it is not nil, and sorting partitions too short for radix
+// sort with short.
+// Slices made of a few sorted runs, and slices of values within a narrow span, are left to sortRuns and
+// sortLowSpan before a buffer is taken. Slices too large for the CPU cache are partitioned first, see hybridSort.
+func radixSort[T Integer](x, buffer []T, size uint, minval T, short shortSort[T], pass passFunc) error {
+	if useHybrid(size, len(x)) {
+		return hybridSort(x, buffer, size, minval, 0, short, 1, pass)
+	}
+	return lsdRadixSort(x, buffer, size, minval, pass)
+}
+
+// lsdRadixSort is radixSort, sorting the least significant digit first. Large slices are sorted with wider digits,
+// see digitBits.
+//
+// One read of x counts the bytes at every radix offset at once. As a permutation of x has the same counts,
+// these are valid for every pass. Passes where all elements share the same byte are skipped,
+// as is sorting at all if x is found to be already sorted.
+func lsdRadixSort[T Integer](x, buffer []T, size uint, minval T, pass passFunc) error {
+	if bits := digitBits(size, len(x)); bits != radix {
+		return wideRadixSort(x, buffer, size, bits, minval, pass)
+	}
+	var counts [maxPasses][256]int
+	passes := int(size / radix)
+	if histograms(x, counts[:passes], minval) { // Short-circuit sorted
+		return nil
+	}
+	var moving [maxPasses]int
+	return scatterPasses(x, buffer, movingPasses(&moving, passes, len(x), func(p int) []int {
+		return counts[p][:]
+	}), 0, pass, func(from, to []T, p int, _, _ bool) {
+		offset := &counts[p] // Keep track of where room is made for byte groups in the buffer
+		keyOffset := uint(p) * radix
 
 		// Find target bucket offsets
-		var watermark int
-		if minval != 0 && keyOffset == size-radix {
-			// Handle signed values
-			// Negatives
-			for i := 128; i < len(offset); i++ {
-				count := offset[i]
-				offset[i] = watermark
-				watermark += count
-			}
-			// Positives
-			for i := 0; i < 128; i++ {
-				count := offset[i]
-				offset[i] = watermark
-				watermark += count
-			}
-		} else {
-			for i, count := range offset {
-				offset[i] = watermark
-				watermark += count
+		bucketOffsets(offset, minval != 0 && p == passes-1)
+
+		// Swap values between the buffers by radix
+		if !specializedScatter(from, to, offset, keyOffset) {
+			for _, elem := range from {
+				key := uint8(elem >> keyOffset) // Get the byte of each element at the radix
+				to[offset[key]] = elem          // Copy the element depending on byte offsets
+				offset[key]++
 			}
 		}
+	})
+}
 
-		// Swap values between the buffers by radix
-		for _, elem := range from {
-			key = uint8(elem >> keyOffset) // Get the byte of each element at the radix
-			to[offset[key]] = elem         // Copy the element depending on byte offsets
-			offset[key]++
+// movingPasses returns, in moving, those of the first passes that would move any of n elements, going by the counts
+// of each pass. Passes where all elements share the same digit are left out.
+func movingPasses(moving *[maxPasses]int, passes, n int, counts func(p int) []int) []int {
+	m := moving[:0]
+	for p := 0; p < passes; p++ {
+		if !trivialPass(counts(p), n) {
+			m = append(m, p)
 		}
+	}
+	return m
+}
+
+// scatterPasses runs the passes of a least significant digit first radix sort. For each of moving in order,
+// scatter moves every element of from into to by its digit for pass p, then x and buffer swap roles, so that x ends
+// up sorted. first and last tell scatter if p is the first or last of moving.
+//
+// If pass is not nil, it is called with p+1 after each pass. If it returns an error, sorting stops and x is left
+// holding all of its elements. If floatBits is not zero, elements are the bits of floats that wide, flipped by the
+// first pass and unflipped by the last, see flipFloat. Elements stopped in between are unflipped, and an error
+// returned for the last pass is ignored, as x is sorted by then, see floatBitsSort.
+func scatterPasses[T Integer](x, buffer []T, moving []int, floatBits uint, pass passFunc,
+	scatter func(from, to []T, p int, first, last bool)) error {
+	from := x
+	to := buffer[:len(x)]
+	for i, p := range moving {
+		last := i == len(moving)-1
+		scatter(from, to, p, i == 0, last)
 
 		// Reverse buffers on each pass
 		from, to = to, from
+
+		if pass != nil {
+			if err := pass(p + 1); err != nil && (floatBits == 0 || !last) {
+				switch {
+				case floatBits != 0: // Elements are flipped in from
+					unflipFloatBits(x, from, floatBits)
+				case i&1 == 0: // x is in the buffer
+					copy(to, from)
+				}
+				return err
+			}
+		}
 	}
 
 	// copy from buffer if done during odd turn
-	if radix&keyOffset == radix {
+	if len(moving)&1 == 1 {
 		copy(to, from)
 	}
+	return nil
+}
+
+// histograms counts the bytes at each radix offset of the elements of x, one offset per entry in counts.
+// It returns true if x is sorted.
+func histograms[T Integer](x []T, counts [][256]int, minval T) bool {
+	if sorted, ok := specializedHistograms(x, counts); ok {
+		return sorted
+	}
+	var (
+		prev   = minval
+		sorted = true
+	)
+	// Larger elements are widened to uint64, as shifts past the size of T are rejected for smaller types
+	switch len(counts) {
+	case 1:
+		c0 := &counts[0]
+		for _, elem := range x {
+			c0[uint8(elem)]++
+			if sorted { // Detect sorted
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	case 2:
+		c0, c1 := &counts[0], &counts[1]
+		for _, elem := range x {
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	case 4:
+		c0, c1, c2, c3 := &counts[0], &counts[1], &counts[2], &counts[3]
+		for _, elem := range x {
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	default:
+		c0, c1, c2, c3 := &counts[0], &counts[1], &counts[2], &counts[3]
+		c4, c5, c6, c7 := &counts[4], &counts[5], &counts[6], &counts[7]
+		for _, elem := range x {
+			u := uint64(elem)
+			c0[uint8(u)]++
+			c1[uint8(u>>8)]++
+			c2[uint8(u>>16)]++
+			c3[uint8(u>>24)]++
+			c4[uint8(u>>32)]++
+			c5[uint8(u>>40)]++
+			c6[uint8(u>>48)]++
+			c7[uint8(u>>56)]++
+			if sorted {
+				sorted = elem >= prev
+				prev = elem
+			}
+		}
+	}
+	return sorted
+}
+
+// trivialPass returns true if all n elements counted in counts have the same byte, so a pass would not move them.
+func trivialPass(counts []int, n int) bool {
+	for _, count := range counts {
+		if count != 0 {
+			return count == n
+		}
+	}
+	return true
+}
+
+// bucketOffsets replaces byte counts with the offset of the first element with that byte.
+// If signed, bytes with the top bit set, which are negative, go first.
+func bucketOffsets(counts *[256]int, signed bool) {
+	var watermark int
+	if signed {
+		// Negatives
+		for i := 128; i < len(counts); i++ {
+			count := counts[i]
+			counts[i] = watermark
+			watermark += count
+		}
+		// Positives
+		for i := 0; i < 128; i++ {
+			count := counts[i]
+			counts[i] = watermark
+			watermark += count
+		}
+	} else {
+		for i, count := range counts {
+			counts[i] = watermark
+			watermark += count
+		}
+	}
 }
@@ -40,6 +40,16 @@ func TestSortBYOB(t *testing.T) {
 	testSort[uint](t, internal.RandInteger[uint](), true)
 }
 
+func TestSortNarrow(t *testing.T) {
+	// Values that share most of their bytes, so most passes are skipped
+	testSort[int16](t, func() int16 { return int16(internal.RandInteger[int8]()()) }, false)
+	testSort[int32](t, func() int32 { return int32(internal.RandInteger[int8]()()) }, false)
+	testSort[int64](t, func() int64 { return int64(internal.RandInteger[int16]()()) << 24 }, true)
+	testSort[uint32](t, func() uint32 { return uint32(internal.RandInteger[uint8]()()) << 16 }, false)
+	testSort[uint64](t, func() uint64 { return uint64(internal.RandInteger[uint16]()()) }, true)
+	testSort[uint64](t, func() uint64 { return uint64(internal.RandInteger[uint8]()()) | 1<<40 }, false)
+}
+
 func testSort[N Integer](t *testing.T, rng func() N, byob bool) {
 	for i := 0; i <= testSize; i++ {
 		toTest := make([]N, i)